@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/request"
+	"github.com/fiber-go-template/helper/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminController struct {
+	Settings *settings.Reloader
+}
+
+func NewAdminController(reloader *settings.Reloader) AdminController {
+	return AdminController{
+		Settings: reloader,
+	}
+}
+
+// GetLogLevel method to get the current log level.
+// @Description Get the current log level of the server.
+// @Summary get log level
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} response.Base{data=models.LogLevel}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/admin/log-level [get]
+func (h *AdminController) GetLogLevel(c *fiber.Ctx) error {
+	return response.OK(c, models.LogLevel{Level: logger.Level()})
+}
+
+// SetLogLevel method to change the log level without a restart.
+// @Description Change the log level of the server at runtime.
+// @Summary change log level
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param data body models.LogLevel true "Log level"
+// @Success 200 {object} response.Base{data=models.LogLevel}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/admin/log-level [put]
+func (h *AdminController) SetLogLevel(c *fiber.Ctx) error {
+	level, err := request.Bind[models.LogLevel](c)
+	if err != nil {
+		return err
+	}
+
+	if err := logger.SetLevel(level.Level); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	return response.OK(c, models.LogLevel{Level: logger.Level()}, "Log level changed successfully")
+}
+
+// GetConfig method to get the effective configuration.
+// @Description Get the effective configuration of the server, secrets are redacted.
+// @Summary get configuration
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} response.Base{data=object}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/admin/config [get]
+func (h *AdminController) GetConfig(c *fiber.Ctx) error {
+	return response.OK(c, h.Settings.Config().Redacted())
+}
+
+// ReloadConfig method to reload the configuration, like SIGHUP does.
+// @Description Reload the configuration, an invalid configuration is rejected and the current one stays active.
+// @Summary reload configuration
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} response.Base{data=models.ConfigReload}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/admin/config/reload [post]
+func (h *AdminController) ReloadConfig(c *fiber.Ctx) error {
+	restart, err := h.Settings.Reload()
+	if err != nil {
+		var invalid *settings.Error
+		if errors.As(err, &invalid) {
+			return apperror.Validation("invalid configuration").WithFields(invalid.Fields())
+		}
+		return apperror.Internal(err)
+	}
+
+	if restart == nil {
+		restart = []string{}
+	}
+	return response.OK(c, models.ConfigReload{RestartRequired: restart}, "Configuration reloaded successfully")
+}
@@ -1,22 +1,31 @@
 package controllers
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/fiber-go-template/app/models"
 	"github.com/fiber-go-template/app/services"
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
 	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/metrics"
+	"github.com/fiber-go-template/helper/request"
+	"github.com/fiber-go-template/helper/response"
 	"github.com/gofiber/fiber/v2"
 )
 
+// AuthController signs the tokens with the keys of the effective configuration,
+// read on each request so that rotated keys apply without a restart.
 type AuthController struct {
 	UserService services.UserService
+	Settings    *settings.Reloader
 }
 
-func NewAuthController(service services.UserService) AuthController {
+func NewAuthController(service services.UserService, reloader *settings.Reloader) AuthController {
 	return AuthController{
 		UserService: service,
+		Settings:    reloader,
 	}
 }
 
@@ -27,54 +36,43 @@ func NewAuthController(service services.UserService) AuthController {
 // @Accept json
 // @Produce json
 // @Param data body models.SignIn true "Data user"
-// @Success 200 {string} status "ok"
+// @Success 200 {object} response.Base{data=models.AuthResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
 // @Router /v1/user/login [post]
 func (h *AuthController) UserSignIn(c *fiber.Ctx) error {
-	signIn := &models.SignIn{}
-
 	// Checking received data from JSON body.
-	if err := c.BodyParser(signIn); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+	signIn, err := request.Bind[models.SignIn](c)
+	if err != nil {
+		return err
 	}
 
 	// Get user by email.
-	foundedUser, err := h.UserService.GetUserByUsername(signIn.Username)
+	foundedUser, err := h.UserService.GetUserByUsername(c.UserContext(), signIn.Username)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "user with the given email is not found",
-		})
+		if appErr, ok := apperror.As(err); ok && appErr.Status == fiber.StatusNotFound {
+			metrics.LoginAttempts.WithLabelValues(metrics.LoginFailure).Inc()
+		}
+		return err
 	}
 
 	// Compare given user password with stored in found user.
 	compareUserPassword := utils.ComparePasswords(foundedUser.Password, signIn.Password)
 	if !compareUserPassword {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "wrong user username address or password",
-		})
+		metrics.LoginAttempts.WithLabelValues(metrics.LoginFailure).Inc()
+		return apperror.Unauthorized("wrong user username address or password")
 	}
 
 	// Generate a new pair of access and refresh tokens.
 	var credentials []string
-	tokens, err := utils.GenerateNewTokens(foundedUser.ID.String(), credentials)
+	tokens, err := utils.GenerateNewTokens(h.Settings.Config().JWT, foundedUser.ID.String(), credentials)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+		return apperror.Internal(err)
 	}
+	metrics.LoginAttempts.WithLabelValues(metrics.LoginSuccess).Inc()
 
-	return c.JSON(fiber.Map{
-		"data": foundedUser,
-		"token": fiber.Map{
-			"accessToken": tokens.Access,
-			"refresh":     tokens.Refresh,
-		},
-	})
+	return h.sendTokens(c, foundedUser, tokens)
 }
 
 // UserSignOut method to de-authorize user and delete refresh token from Redis.
@@ -83,23 +81,24 @@ func (h *AuthController) UserSignIn(c *fiber.Ctx) error {
 // @Tags User
 // @Accept json
 // @Produce json
-// @Success 204 {string} status "ok"
+// @Success 204
+// @Failure 401 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/user/logout [post]
 func (h *AuthController) UserSignOut(c *fiber.Ctx) error {
 	// Get claims from JWT.
 	claims, err := utils.ExtractTokenMetadata(c)
 	if err != nil {
-		// Return status 500 and JWT parse error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+		return apperror.Unauthorized(err.Error())
 	}
 
-	fmt.Println(claims)
+	logger.Ctx(c.UserContext()).Debug().Str("userId", claims.UserID.String()).Msg("user signed out")
+
+	if cookie := h.Settings.Config().AuthCookie; cookie.Enabled {
+		utils.ClearAuthCookies(c, cookie)
+	}
 
-	return c.SendStatus(fiber.StatusNoContent)
+	return response.NoContent(c)
 }
 
 // RenewTokens method for renew access and refresh tokens.
@@ -108,47 +107,35 @@ func (h *AuthController) UserSignOut(c *fiber.Ctx) error {
 // @Tags Token
 // @Accept json
 // @Produce json
-// @Param refresh_token body string true "Refresh token"
-// @Success 200 {string} status "ok"
+// @Param data body models.Renew false "Refresh token, read from the refresh_token cookie in cookie auth mode"
+// @Success 200 {object} response.Base{data=models.AuthResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/token/renew [post]
 func (h *AuthController) RenewTokens(c *fiber.Ctx) error {
-	// Get claims from JWT.
+	// Get claims from JWT, checking the Access token expiration time.
 	now := time.Now().Unix()
-	claims, err := utils.ExtractTokenMetadata(c)
+	claims, err := tokenClaims(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+		return err
 	}
 
-	// Checking, if now time greather than Access token expiration time.
-	expiresAccessToken := claims.Expires
-	if now > expiresAccessToken {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "unauthorized, check expiration time of your token",
-		})
-	}
-
-	renew := &models.Renew{}
-
-	// Checking received data from JSON body.
-	if err := c.BodyParser(renew); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+	// Checking received refresh token, from the cookie in cookie auth mode or the JSON body.
+	refreshToken := c.Cookies(utils.RefreshTokenCookie)
+	if refreshToken == "" || !h.Settings.Config().AuthCookie.Enabled {
+		renew, err := request.Bind[models.Renew](c)
+		if err != nil {
+			return err
+		}
+		refreshToken = renew.RefreshToken
 	}
 
 	// Set expiration time from Refresh token of current user.
-	expiresRefreshToken, err := utils.ParseRefreshToken(renew.RefreshToken)
+	expiresRefreshToken, err := utils.ParseRefreshToken(refreshToken)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+		return apperror.BadRequest("invalid refresh token").WithField("refresh_token", err.Error())
 	}
 
 	// Checking, if now time greather than Refresh token expiration time.
@@ -156,35 +143,40 @@ func (h *AuthController) RenewTokens(c *fiber.Ctx) error {
 		userID := claims.UserID.String()
 
 		// Get user by ID.
-		foundedUser, err := h.UserService.GetUserByID(userID)
+		foundedUser, err := h.UserService.GetUserByID(c.UserContext(), userID)
 		if err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "user with the given ID is not found",
-			})
+			return err
 		}
 
 		// Generate JWT Access & Refresh tokens.
 		var credentials []string
-		tokens, err := utils.GenerateNewTokens(userID, credentials)
+		tokens, err := utils.GenerateNewTokens(h.Settings.Config().JWT, userID, credentials)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"error":   err.Error(),
-			})
+			return apperror.Internal(err)
 		}
 
-		return c.JSON(fiber.Map{
-			"data": foundedUser,
-			"token": fiber.Map{
-				"accessToken": tokens.Access,
-				"refresh":     tokens.Refresh,
-			},
-		})
+		return h.sendTokens(c, foundedUser, tokens)
 	} else {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "unauthorized, your session was ended earlier",
-		})
+		return apperror.Unauthorized("unauthorized, your session was ended earlier")
+	}
+}
+
+// sendTokens method sends the signed in user with its tokens, never exposing the password hash.
+// In cookie auth mode the tokens are set in HttpOnly cookies instead of the body.
+func (h *AuthController) sendTokens(c *fiber.Ctx, user models.User, tokens *utils.Tokens) error {
+	user.Password = ""
+	res := models.AuthResponse{User: user}
+
+	if cfg := h.Settings.Config(); cfg.AuthCookie.Enabled {
+		if err := utils.SetAuthCookies(c, cfg.AuthCookie, cfg.JWT, tokens); err != nil {
+			return apperror.Internal(err)
+		}
+		return response.OK(c, res)
+	}
+
+	res.Token = &models.Token{
+		AccessToken: tokens.Access,
+		Refresh:     tokens.Refresh,
 	}
+	return response.OK(c, res)
 }
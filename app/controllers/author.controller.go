@@ -1,23 +1,20 @@
 package controllers
 
 import (
-	"strconv"
-	"time"
-
 	"github.com/fiber-go-template/app/models"
 	"github.com/fiber-go-template/app/services"
-	"github.com/fiber-go-template/config/utils"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofrs/uuid"
 )
 
 type AuthorController struct {
+	CRUDController[models.Author, models.AuthorRequest, *models.AuthorRequest]
 	AuthorService services.AuthorService
 }
 
 func NewAuthorController(service services.AuthorService) AuthorController {
 	return AuthorController{
-		AuthorService: service,
+		CRUDController: NewCRUDController[models.Author, models.AuthorRequest](service, models.MappingAuthor),
+		AuthorService:  service,
 	}
 }
 
@@ -27,54 +24,26 @@ func NewAuthorController(service services.AuthorService) AuthorController {
 // @Tags Author
 // @Produce json
 // @Param q query string false "Keyword search"
-// @Param pageSize query int true "Set pageSize data"
-// @Param pageNumber query int true "Set page number"
+// @Param pageSize query int false "Set pageSize data" default(10)
+// @Param pageNumber query int false "Set page number for pagingMode offset" default(1)
 // @Param sortBy query string false "Set sortBy parameter is one of [ nama ]"
-// @Param sortType query string false "Set sortType with asc or desc"
-// @Success 200 {object} response.Base
-// @Failure 400 {object} response.Base
-// @Failure 500 {object} response.Base
+// @Param sortType query string false "Set sortType with asc or desc" default(DESC)
+// @Param startDate query string false "Filter createdAt from this date (YYYY-MM-DD or RFC3339)"
+// @Param endDate query string false "Filter createdAt until this date (YYYY-MM-DD or RFC3339)"
+// @Param filter[field][operator] query string false "Filter by field, e.g. filter[name][contains]=foo. Fields: id, name, address, createdAt, updatedAt. Operators: eq, ne, gt, gte, lt, lte, contains, startsWith, endsWith, in, isNull"
+// @Param pagingMode query string false "Set pagingMode with offset (default) or cursor"
+// @Param after query string false "Cursor of the last item seen, for pagingMode cursor"
+// @Param before query string false "Cursor of the first item seen, for pagingMode cursor"
+// @Param skipCount query bool false "Skip the total count in pagingMode cursor"
+// @Param fields query string false "Comma separated fields to return, e.g. id,name"
+// @Success 200 {object} response.Base{data=[]models.Author,meta=response.Meta}
+// @Failure 400 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/authors [get]
 func (h *AuthorController) ResolveAll(c *fiber.Ctx) error {
-	keyword := c.Query("keyword")
-	pageSizeStr := c.Query("pageSize")
-	pageNumberStr := c.Query("pageNumber")
-	sortBy := c.Query("sortBy")
-	if sortBy == "" {
-		sortBy = "createdAt"
-	}
-
-	sortType := c.Query("sortType")
-	if sortType == "" {
-		sortType = "DESC"
-	}
-	pageSize, err := strconv.Atoi(pageSizeStr)
-	if err != nil {
-		return err
-	}
-
-	pageNumber, err := strconv.Atoi(pageNumberStr)
-	if err != nil {
-		return err
-	}
-
-	req := models.StandardRequest{
-		Keyword:    keyword,
-		PageSize:   pageSize,
-		PageNumber: pageNumber,
-		SortBy:     sortBy,
-		SortType:   sortType,
-	}
-
-	data, err := h.AuthorService.ResolveAll(req)
-	if err != nil {
-		return err
-	}
-
-	return c.JSON(fiber.Map{
-		"data": data,
-	})
+	return h.CRUDController.ResolveAll(c)
 }
 
 // GetAll func gets all exists authors.
@@ -83,27 +52,17 @@ func (h *AuthorController) ResolveAll(c *fiber.Ctx) error {
 // @Tags Author
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Base{models.Author}
-// @Failure 400 {object} response.Base
-// @Failure 500 {object} response.Base
+// @Param startDate query string false "Filter createdAt from this date (YYYY-MM-DD or RFC3339)"
+// @Param endDate query string false "Filter createdAt until this date (YYYY-MM-DD or RFC3339)"
+// @Param filter[field][operator] query string false "Filter by field, e.g. filter[name][contains]=foo"
+// @Param fields query string false "Comma separated fields to return, e.g. id,name"
+// @Success 200 {object} response.Base{data=[]models.Author}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/authors/all [get]
 func (h *AuthorController) GetAll(c *fiber.Ctx) error {
-	// Get all data
-	data, err := h.AuthorService.GetAll()
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data were not found",
-			"data":    nil,
-		})
-	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": nil,
-		"data":    data,
-	})
+	return h.CRUDController.GetAll(c)
 }
 
 // FindByID func gets author by given ID or 404 error.
@@ -113,36 +72,15 @@ func (h *AuthorController) GetAll(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Author ID"
-// @Success 200 {object} response.Base{models.Author}
-// @Failure 400 {object} response.Base
-// @Failure 500 {object} response.Base
+// @Param fields query string false "Comma separated fields to return, e.g. id,name"
+// @Success 200 {object} response.Base{data=models.Author}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/author/{id} [get]
 func (h *AuthorController) FindByID(c *fiber.Ctx) error {
-	// Catch data ID from URL.
-	id, err := uuid.FromString(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Get data by ID.
-	data, err := h.AuthorService.FindByID(id)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data with the given ID is not found",
-			"data":    nil,
-		})
-	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": nil,
-		"data":    data,
-	})
+	return h.CRUDController.FindByID(c)
 }
 
 // Create func for creates a new author.
@@ -152,65 +90,16 @@ func (h *AuthorController) FindByID(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param data body models.AuthorRequest true "Author"
-// @Success 200 {object} response.Base{data=models.Author}
-// @Failure 400 {object} response.Base
-// @Failure 500 {object} response.Base
+// @Param Idempotency-Key header string false "Key replaying the first response of retried requests"
+// @Success 201 {object} response.Base{data=models.Author}
+// @Failure 400 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/author [post]
 func (h *AuthorController) Create(c *fiber.Ctx) error {
-	now := time.Now().Unix()
-	// Get claims from JWT.
-	claims, err := utils.ExtractTokenMetadata(c)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Checking, if now time greather than expiration from JWT.
-	expires := claims.Expires
-	if now > expires {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "unauthorized, check expiration time of your token",
-		})
-	}
-
-	var request models.AuthorRequest
-
-	// Check, if received JSON data is valid.
-	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Validate author fields.
-	validate := utils.NewValidator()
-	if err := validate.Struct(request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   utils.ValidatorErrors(err),
-		})
-	}
-
-	// Create author by given model.
-	request.UserID = claims.UserID
-	data, err := h.AuthorService.Create(request)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Create data successfully",
-		"data":    data,
-	})
+	return h.CRUDController.Create(c)
 }
 
 // Update func for updates author by given ID.
@@ -221,83 +110,15 @@ func (h *AuthorController) Create(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "Author ID"
 // @Param data body models.AuthorRequest true "Author"
-// @Success 200 {object} response.Base{models.Author}
-// @Failure 400 {object} response.Base
-// @Failure 500 {object} response.Base
+// @Success 200 {object} response.Base{data=models.Author}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/author/{id} [put]
 func (h *AuthorController) Update(c *fiber.Ctx) error {
-	id, err := uuid.FromString(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Get claims from JWT.
-	now := time.Now().Unix()
-	claims, err := utils.ExtractTokenMetadata(c)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Checking, if now time greather than expiration from JWT.
-	expires := claims.Expires
-	if now > expires {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "unauthorized, check expiration time of your token",
-		})
-	}
-
-	var request models.AuthorRequest
-
-	// Check, if received JSON data is valid.
-	if err := c.BodyParser(&request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Checking, if author with given ID is exists.
-	foundedAuthor, err := h.AuthorService.FindByID(id)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data with this ID not found",
-		})
-	}
-
-	// Validate author fields.
-	validate := utils.NewValidator()
-	if err := validate.Struct(request); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   utils.ValidatorErrors(err),
-		})
-	}
-
-	// Update author by given ID.
-	request.ID = id
-	request.UserID = claims.UserID
-	data, err := h.AuthorService.Update(foundedAuthor.ID, request)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "Update data successfully",
-		"data":    data,
-	})
+	return h.CRUDController.Update(c)
 }
 
 // Delete func for delete author by given ID.
@@ -308,58 +129,11 @@ func (h *AuthorController) Update(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "Author ID"
 // @Success 200 {object} response.Base
-// @Failure 400 {object} response.Base
-// @Failure 500 {object} response.Base
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Security ApiKeyAuth
 // @Router /v1/author/{id} [delete]
 func (h *AuthorController) Delete(c *fiber.Ctx) error {
-	id, err := uuid.FromString(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Get claims from JWT.
-	now := time.Now().Unix()
-	claims, err := utils.ExtractTokenMetadata(c)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	// Checking, if now time greather than expiration from JWT.
-	expires := claims.Expires
-	if now > expires {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"error":   "unauthorized, check expiration time of your token",
-		})
-	}
-
-	// Checking, if author with given ID is exists.
-	foundedAuthor, err := h.AuthorService.FindByID(id)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data with this ID not found",
-		})
-	}
-
-	// Set user ID from JWT data of current user.
-	err = h.AuthorService.Delete(foundedAuthor.ID, claims.UserID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
-	}
-
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "Delete data successfully",
-	})
+	return h.CRUDController.Delete(c)
 }
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/app/services"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/fieldset"
+	"github.com/fiber-go-template/helper/filter"
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/fiber-go-template/helper/request"
+	"github.com/fiber-go-template/helper/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+// CRUDController provides list, detail, create, update and soft delete handlers for
+// a model T created from request R. Resource controllers embed it, document the
+// routes with swag annotations and override handlers when needed.
+type CRUDController[T any, R any, PR models.Payload[R]] struct {
+	Service services.Service[T, R]
+	Mapping models.Mapping
+}
+
+func NewCRUDController[T any, R any, PR models.Payload[R]](service services.Service[T, R], mapping models.Mapping) CRUDController[T, R, PR] {
+	return CRUDController[T, R, PR]{
+		Service: service,
+		Mapping: mapping,
+	}
+}
+
+// ResolveAll lists the data with offset or cursor pagination.
+func (h *CRUDController[T, R, PR]) ResolveAll(c *fiber.Ctx) error {
+	req, err := request.Bind[models.StandardRequest](c)
+	if err != nil {
+		return err
+	}
+
+	// The keyword parameter is still accepted for clients written before q.
+	if req.Keyword == "" {
+		req.Keyword = c.Query("keyword")
+	}
+	if req.SortBy == "" {
+		req.SortBy = h.Mapping.DefaultSort
+	}
+	if req.After != "" || req.Before != "" {
+		req.PagingMode = pagination.ModeCursor
+	}
+
+	// Page number is meaningless when paging by cursor.
+	if req.PagingMode == pagination.ModeCursor {
+		req.PageNumber = 1
+	}
+
+	if req.Filters, err = h.parseFilters(c); err != nil {
+		return err
+	}
+
+	if req.Fields, err = h.parseFields(c); err != nil {
+		return err
+	}
+
+	data, err := h.Service.ResolveAll(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	if req.Fields == nil {
+		return response.Paginated(c, data)
+	}
+
+	projected, err := fieldset.ProjectPage(req.Fields, data)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	return response.Paginated(c, projected)
+}
+
+// GetAll gets all exists data.
+func (h *CRUDController[T, R, PR]) GetAll(c *fiber.Ctx) error {
+	filters, err := h.parseFilters(c)
+	if err != nil {
+		return err
+	}
+
+	fields, err := h.parseFields(c)
+	if err != nil {
+		return err
+	}
+
+	// Get all data
+	data, err := h.Service.GetAll(c.UserContext(), filters, fields)
+	if err != nil {
+		return err
+	}
+
+	if fields == nil {
+		return response.OK(c, data)
+	}
+
+	projected, err := fieldset.ProjectAll(fields, data)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	return response.OK(c, projected)
+}
+
+// FindByID gets data by given ID or 404 error.
+func (h *CRUDController[T, R, PR]) FindByID(c *fiber.Ctx) error {
+	// Catch data ID from URL.
+	id, err := parseID(c)
+	if err != nil {
+		return err
+	}
+
+	fields, err := h.parseFields(c)
+	if err != nil {
+		return err
+	}
+
+	// Get data by ID.
+	data, err := h.Service.FindByID(c.UserContext(), id, fields)
+	if err != nil {
+		return err
+	}
+
+	if fields == nil {
+		return response.OK(c, data)
+	}
+
+	projected, err := fields.Project(data)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	return response.OK(c, projected)
+}
+
+// Create creates a new data.
+func (h *CRUDController[T, R, PR]) Create(c *fiber.Ctx) error {
+	// Get claims from JWT.
+	claims, err := tokenClaims(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate the request body.
+	body, err := request.Bind[R](c)
+	if err != nil {
+		return err
+	}
+
+	// Create data by given request.
+	PR(&body).SetIdentity(uuid.Nil, claims.UserID)
+	data, err := h.Service.Create(c.UserContext(), body)
+	if err != nil {
+		return err
+	}
+
+	return response.Created(c, data, "Create data successfully")
+}
+
+// Update updates data by given ID.
+func (h *CRUDController[T, R, PR]) Update(c *fiber.Ctx) error {
+	id, err := parseID(c)
+	if err != nil {
+		return err
+	}
+
+	// Get claims from JWT.
+	claims, err := tokenClaims(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate the request body.
+	body, err := request.Bind[R](c)
+	if err != nil {
+		return err
+	}
+
+	// Update data by given ID, the service returns not found for unknown IDs.
+	PR(&body).SetIdentity(id, claims.UserID)
+	data, err := h.Service.Update(c.UserContext(), id, body)
+	if err != nil {
+		return err
+	}
+
+	return response.OK(c, data, "Update data successfully")
+}
+
+// Delete soft deletes data by given ID.
+func (h *CRUDController[T, R, PR]) Delete(c *fiber.Ctx) error {
+	id, err := parseID(c)
+	if err != nil {
+		return err
+	}
+
+	// Get claims from JWT.
+	claims, err := tokenClaims(c)
+	if err != nil {
+		return err
+	}
+
+	// Set user ID from JWT data of current user.
+	err = h.Service.Delete(c.UserContext(), id, claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	return response.Message(c, "Delete data successfully")
+}
+
+// parseFilters reads filter[...] and the startDate/endDate and status shortcuts from the query string.
+func (h *CRUDController[T, R, PR]) parseFilters(c *fiber.Ctx) ([]filter.Condition, error) {
+	filters, err := filter.Parse(c.Queries(), h.Mapping.Filters)
+	if err != nil {
+		return nil, filterError(err)
+	}
+
+	dateFilters, err := filter.DateRange(h.Mapping.Filters, h.Mapping.DateField, c.Query("startDate"), c.Query("endDate"))
+	if err != nil {
+		return nil, filterError(err)
+	}
+	filters = append(filters, dateFilters...)
+
+	if status := c.Query("status"); status != "" {
+		if h.Mapping.StatusField == "" {
+			return nil, filterError(&filter.Error{Field: "status", Message: "field is not filterable"})
+		}
+
+		condition, err := filter.NewCondition(h.Mapping.Filters, h.Mapping.StatusField, filter.OpEq, status)
+		if err != nil {
+			return nil, filterError(err)
+		}
+		filters = append(filters, condition)
+	}
+
+	return filters, nil
+}
+
+// parseFields reads the ?fields= sparse fieldset.
+func (h *CRUDController[T, R, PR]) parseFields(c *fiber.Ctx) (fieldset.Fieldset, error) {
+	fields, err := fieldset.Parse(c.Query("fields"), h.Mapping.Fields)
+	if err != nil {
+		var fieldErr *fieldset.Error
+		if errors.As(err, &fieldErr) {
+			return nil, apperror.BadRequest("invalid fields parameter").WithField(fieldErr.Field, "field is not available")
+		}
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	return fields, nil
+}
+
+func filterError(err error) error {
+	var filterErr *filter.Error
+	if errors.As(err, &filterErr) {
+		return apperror.BadRequest("invalid filter").WithField(filterErr.Field, filterErr.Message, filterErr.Args...)
+	}
+	return apperror.BadRequest(err.Error())
+}
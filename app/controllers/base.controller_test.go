@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/filter"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilters(t *testing.T) {
+	fields := filter.Fields{
+		"name":      {Column: "name", Type: filter.TypeString, Operators: filter.StringOperators},
+		"status":    {Column: "status", Type: filter.TypeNumber, Operators: filter.EqualityOperators},
+		"createdAt": {Column: "created_at", Type: filter.TypeTime, Operators: filter.ComparableOperators},
+	}
+	withStatus := models.Mapping{Filters: fields, DateField: "createdAt", StatusField: "status"}
+	withoutStatus := models.Mapping{Filters: fields, DateField: "createdAt"}
+
+	tests := []struct {
+		name    string
+		mapping models.Mapping
+		query   string
+		want    []string
+		status  int
+	}{
+		{"no filters", withStatus, "", nil, fiber.StatusOK},
+		{"filter and date range", withStatus, "?filter[name][contains]=pram&startDate=2024-01-01&endDate=2024-01-31",
+			[]string{"name contains", "createdAt gte", "createdAt lt"}, fiber.StatusOK},
+		{"status", withStatus, "?status=1", []string{"status eq"}, fiber.StatusOK},
+		{"status and filters", withStatus, "?status=1&filter[name]=x", []string{"name eq", "status eq"}, fiber.StatusOK},
+		{"invalid status", withStatus, "?status=active", nil, fiber.StatusBadRequest},
+		{"model without status", withoutStatus, "?status=1", nil, fiber.StatusBadRequest},
+		{"unknown field", withStatus, "?filter[password]=x", nil, fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := CRUDController[models.Author, models.AuthorRequest, *models.AuthorRequest]{Mapping: tt.mapping}
+
+			var got []string
+			app := fiber.New(fiber.Config{ErrorHandler: apperror.ErrorHandler})
+			app.Get("/", func(c *fiber.Ctx) error {
+				conditions, err := controller.parseFilters(c)
+				for _, condition := range conditions {
+					got = append(got, condition.Field+" "+condition.Operator)
+				}
+				return err
+			})
+
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/"+tt.query, nil), -1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"github.com/fiber-go-template/helper/health"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthController struct {
+	Checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) HealthController {
+	return HealthController{
+		Checker: checker,
+	}
+}
+
+// Live method to report the process is running.
+// @Description Liveness probe, up while the process is able to serve requests.
+// @Summary liveness probe
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Router /health/live [get]
+func (h *HealthController) Live(c *fiber.Ctx) error {
+	return c.JSON(health.Report{Status: health.StatusUp, Checks: map[string]health.CheckResult{}})
+}
+
+// Ready method to report whether the dependencies are reachable.
+// @Description Readiness probe, pings the database pools and Redis. Not ready during graceful shutdown.
+// @Summary readiness probe
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/ready [get]
+func (h *HealthController) Ready(c *fiber.Ctx) error {
+	report := h.Checker.Ready(c.UserContext())
+
+	status := fiber.StatusOK
+	if report.Status != health.StatusReady {
+		status = fiber.StatusServiceUnavailable
+	}
+
+	return c.Status(status).JSON(report)
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+// parseID reads the :id path parameter as UUID.
+func parseID(c *fiber.Ctx) (uuid.UUID, error) {
+	id, err := uuid.FromString(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, apperror.BadRequest("invalid path parameter").WithField("id", "must be a valid UUID")
+	}
+
+	return id, nil
+}
+
+// tokenClaims extracts the JWT claims of the current user and checks their expiration.
+func tokenClaims(c *fiber.Ctx) (*utils.TokenMetadata, error) {
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		return nil, apperror.Unauthorized(err.Error())
+	}
+
+	// Checking, if now time greather than expiration from JWT.
+	if time.Now().Unix() > claims.Expires {
+		return nil, apperror.Unauthorized("unauthorized, check expiration time of your token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+// AccessLog func for log every request with the request-scoped zerolog logger.
+// Errors are rendered by the app error handler first, so the logged status is the one sent.
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		if chainErr := c.Next(); chainErr != nil {
+			if err := c.App().ErrorHandler(c, chainErr); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		l := logger.Ctx(c.UserContext())
+
+		var event *zerolog.Event
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			event = l.Error()
+		case status >= fiber.StatusBadRequest:
+			event = l.Warn()
+		default:
+			event = l.Info()
+		}
+
+		event.
+			Str("method", c.Method()).
+			Str("route", c.Route().Path).
+			Str("path", c.Path()).
+			Int("status", status).
+			Dur("latency", time.Since(start)).
+			Int("bytes", len(c.Response().Body())).
+			Str("ip", c.IP())
+		if userID := tokenUserID(c); userID != "" {
+			event.Str("userId", userID)
+		}
+		event.Msg("request")
+
+		return nil
+	}
+}
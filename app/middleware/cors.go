@@ -1,21 +1,23 @@
 package middleware
 
 import (
-	"os"
-	"strconv"
-
+	"github.com/fiber-go-template/config/settings"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-func setupCORS(app *fiber.App) {
-	allowCredentials, _ := strconv.ParseBool(os.Getenv("CORS_ALLOW_CREDENTIALS"))
-	maxAge, _ := strconv.Atoi(os.Getenv("CORS_MAX_AGE_SECONDS"))
-	app.Use(cors.New(cors.Config{
-		AllowCredentials: allowCredentials,
-		AllowHeaders:     os.Getenv("CORS_ALLOWED_HEADERS"),
-		AllowMethods:     os.Getenv("CORS_ALLOWED_METHODS"),
-		AllowOrigins:     os.Getenv("CORS_ALLOWED_ORIGINS"),
-		MaxAge:           maxAge,
+func setupCORS(app *fiber.App, reloader *settings.Reloader) {
+	app.Use(reloadable(reloader, func(cfg *settings.Config) fiber.Handler {
+		if !cfg.CORS.Enable {
+			return next
+		}
+
+		return cors.New(cors.Config{
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			AllowHeaders:     cfg.CORS.AllowedHeaders,
+			AllowMethods:     cfg.CORS.AllowedMethods,
+			AllowOrigins:     cfg.CORS.AllowedOrigins,
+			MaxAge:           cfg.CORS.MaxAgeSeconds,
+		})
 	}))
 }
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupCSRF func for protect the cookie auth mode against CSRF.
+func setupCSRF(app *fiber.App, cfg settings.AuthCookie) {
+	if cfg.Enabled {
+		app.Use(CSRF())
+	}
+}
+
+// CSRF func for reject unsafe requests authenticated by the access token cookie whose
+// X-CSRF-Token header does not match the CSRF cookie (double-submit).
+// Requests with an Authorization header are not checked, browsers never add it on their own
+// and JWTProtected then ignores the cookie.
+func CSRF() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+			return c.Next()
+		}
+
+		if c.Get(fiber.HeaderAuthorization) != "" || c.Cookies(utils.AccessTokenCookie) == "" {
+			return c.Next()
+		}
+
+		cookie := c.Cookies(utils.CSRFCookie)
+		header := c.Get(utils.HeaderCSRFToken)
+		if cookie == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) != 1 {
+			return apperror.Forbidden("invalid CSRF token")
+		}
+
+		return c.Next()
+	}
+}
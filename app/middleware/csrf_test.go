@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSRF(t *testing.T) {
+	t.Setenv("AUTH_COOKIE_ENABLED", "true")
+	reloader := settings.NewReloader(loadConfig(t, "secret"))
+	token := signedToken(t, "secret")
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.ErrorHandler})
+	app.Use(CSRF())
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/", JWTProtected(reloader), ok)
+	app.Post("/", JWTProtected(reloader), ok)
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		cookie        bool
+		csrfCookie    string
+		csrfHeader    string
+		status        int
+	}{
+		{name: "cookie with matching CSRF token", cookie: true, csrfCookie: "t", csrfHeader: "t", status: fiber.StatusOK},
+		{name: "cookie without CSRF token", cookie: true, status: fiber.StatusForbidden},
+		{name: "cookie with wrong CSRF token", cookie: true, csrfCookie: "t", csrfHeader: "u", status: fiber.StatusForbidden},
+		{name: "bearer header", authorization: "Bearer " + token, status: fiber.StatusOK},
+		{name: "bearer header and cookie", authorization: "Bearer " + token, cookie: true, status: fiber.StatusOK},
+		{name: "other scheme does not fall back to the cookie", authorization: "Basic dXNlcjpwYXNz", cookie: true, status: fiber.StatusBadRequest},
+		{name: "blank bearer does not fall back to the cookie", authorization: "Bearer   ", cookie: true, status: fiber.StatusBadRequest},
+		{name: "safe method", method: fiber.MethodGet, cookie: true, status: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = fiber.MethodPost
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			if tt.cookie {
+				req.Header.Add(fiber.HeaderCookie, utils.AccessTokenCookie+"="+token)
+			}
+			if tt.csrfCookie != "" {
+				req.Header.Add(fiber.HeaderCookie, utils.CSRFCookie+"="+tt.csrfCookie)
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set(utils.HeaderCSRFToken, tt.csrfHeader)
+			}
+
+			res, err := app.Test(req, -1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
@@ -1,17 +1,39 @@
 package middleware
 
 import (
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/helper/i18n"
+	"github.com/fiber-go-template/helper/metrics"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
 // FiberMiddleware provide Fiber's built-in middlewares.
 // See: https://docs.gofiber.io/api/middleware
-func FiberMiddleware(app *fiber.App) {
+func FiberMiddleware(app *fiber.App, reloader *settings.Reloader) {
+	cfg := reloader.Config()
+
+	app.Use(
+		// Tag each request and its logger with a request ID.
+		RequestID(),
+		// Trace each request with OpenTelemetry.
+		Tracing(),
+		// Record Prometheus request metrics.
+		metrics.Middleware(),
+		// Add structured access log.
+		AccessLog(),
+		// Recover from panics and report server errors.
+		Recover(),
+	)
+	// Add security headers.
+	setupSecurityHeaders(app, reloader)
 	// Add CORS to each route.
-	setupCORS(app)
+	setupCORS(app, reloader)
+	// Check CSRF tokens in cookie auth mode.
+	setupCSRF(app, cfg.AuthCookie)
+	// Limit requests per IP, user or API key.
+	setupRateLimit(app, reloader)
 	app.Use(
-		// Add simple logger.
-		logger.New(),
+		// Resolve the locale of messages.
+		i18n.New(),
 	)
 }
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/idempotency"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// maxIdempotencyKeyLength bounds the Idempotency-Key header sent by clients.
+	maxIdempotencyKeyLength = 255
+
+	// idempotencyLockTTL bounds how long a key stays in flight when the instance
+	// handling the request dies, Save keeps the completed response for the full ttl.
+	idempotencyLockTTL = time.Minute
+)
+
+// Idempotency func for replay the first response of requests retried with the same
+// Idempotency-Key header, scoped to the user of JWTProtected and the route.
+// A key reused with a different body returns 422 and a key still in flight returns 409.
+// Responses are kept for ttl, requests without the header are not affected.
+func Idempotency(store idempotency.Store, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		clientKey := c.Get(idempotency.Header)
+		if clientKey == "" {
+			return c.Next()
+		}
+		if len(clientKey) > maxIdempotencyKeyLength {
+			return apperror.BadRequest("invalid %s header", idempotency.Header)
+		}
+
+		ctx := c.UserContext()
+		key := idempotency.Key(tokenUserID(c), c.Method()+" "+c.Route().Path, clientKey)
+		fingerprint := idempotency.Hash(c.Body())
+
+		record, err := store.Lock(ctx, key, fingerprint, idempotencyLockTTL)
+		if errors.Is(err, idempotency.ErrLocked) {
+			return replay(c, record, fingerprint)
+		}
+		if err != nil {
+			// Fail open, an unavailable store must not block the API.
+			logger.Ctx(ctx).Warn().Err(err).Msg("Idempotency store is unavailable.")
+			return c.Next()
+		}
+
+		// Release the key unless the response is stored, also when the handler panics,
+		// so the client may retry.
+		saved := false
+		defer func() {
+			if saved {
+				return
+			}
+			if err := store.Unlock(ctx, key); err != nil {
+				logger.Ctx(ctx).Warn().Err(err).Msg("Failed to release the idempotency key.")
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			// Server errors are not stored, they go up the chain to be reported and the
+			// client may retry them.
+			if errorStatus(err) >= fiber.StatusInternalServerError {
+				return err
+			}
+			// Render the error here to store the response the client receives.
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		// Server errors are not stored, the client may retry them.
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			return nil
+		}
+
+		err = store.Save(ctx, key, idempotency.Record{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}, ttl)
+		if err != nil {
+			logger.Ctx(ctx).Warn().Err(err).Msg("Failed to store the idempotent response.")
+			return nil
+		}
+		saved = true
+
+		return nil
+	}
+}
+
+// replay sends the stored response of a completed request.
+func replay(c *fiber.Ctx, record idempotency.Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return apperror.Validation("%s was already used with a different request", idempotency.Header)
+	}
+	if !record.Completed {
+		return apperror.Conflict("a request with the same %s is still in progress", idempotency.Header)
+	}
+
+	c.Set("Idempotent-Replayed", "true")
+	c.Set(fiber.HeaderContentType, record.ContentType)
+	return c.Status(record.Status).Send(record.Body)
+}
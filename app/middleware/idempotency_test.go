@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/database"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/errorreport"
+	"github.com/fiber-go-template/helper/idempotency"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotentApp(t *testing.T, handler fiber.Handler) *fiber.App {
+	t.Helper()
+
+	conn, err := database.NewMemoryConnection()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Query().Close() })
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.ErrorHandler})
+	app.Use(Recover())
+	app.Post("/authors", Idempotency(idempotency.NewDBStore(conn, time.Hour), time.Hour), handler)
+	return app
+}
+
+func post(t *testing.T, app *fiber.App, key, body string) (int, string, http.Header) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/authors", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	res, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(raw), res.Header
+}
+
+// counter responds 201 with the number of calls.
+func counter(calls *int32) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		n := atomic.AddInt32(calls, 1)
+		return c.Status(fiber.StatusCreated).SendString(strconv.Itoa(int(n)))
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests []struct{ key, body string }
+		statuses []int
+		bodies   []string
+		calls    int32
+	}{
+		{
+			name:     "replays the first response",
+			requests: []struct{ key, body string }{{"a", `{"name":"x"}`}, {"a", `{"name":"x"}`}},
+			statuses: []int{fiber.StatusCreated, fiber.StatusCreated},
+			bodies:   []string{"1", "1"},
+			calls:    1,
+		},
+		{
+			name:     "different body is rejected",
+			requests: []struct{ key, body string }{{"a", `{"name":"x"}`}, {"a", `{"name":"y"}`}},
+			statuses: []int{fiber.StatusCreated, fiber.StatusUnprocessableEntity},
+			calls:    1,
+		},
+		{
+			name:     "different keys run twice",
+			requests: []struct{ key, body string }{{"a", `{}`}, {"b", `{}`}},
+			statuses: []int{fiber.StatusCreated, fiber.StatusCreated},
+			bodies:   []string{"1", "2"},
+			calls:    2,
+		},
+		{
+			name:     "requests without key are not affected",
+			requests: []struct{ key, body string }{{"", `{}`}, {"", `{}`}},
+			statuses: []int{fiber.StatusCreated, fiber.StatusCreated},
+			bodies:   []string{"1", "2"},
+			calls:    2,
+		},
+		{
+			name:     "oversized key",
+			requests: []struct{ key, body string }{{strings.Repeat("k", maxIdempotencyKeyLength+1), `{}`}},
+			statuses: []int{fiber.StatusBadRequest},
+			calls:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			app := newIdempotentApp(t, counter(&calls))
+
+			for i, request := range tt.requests {
+				status, body, _ := post(t, app, request.key, request.body)
+				assert.Equal(t, tt.statuses[i], status, "request %d", i)
+				if tt.bodies != nil {
+					assert.Equal(t, tt.bodies[i], body, "request %d", i)
+				}
+			}
+			assert.Equal(t, tt.calls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestIdempotencyReplayHeader(t *testing.T) {
+	var calls int32
+	app := newIdempotentApp(t, counter(&calls))
+
+	_, _, header := post(t, app, "a", `{}`)
+	assert.Empty(t, header.Get("Idempotent-Replayed"))
+	_, _, header = post(t, app, "a", `{}`)
+	assert.Equal(t, "true", header.Get("Idempotent-Replayed"))
+}
+
+func TestIdempotencyInFlight(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	app := newIdempotentApp(t, func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	done := make(chan int)
+	go func() {
+		status, _, _ := post(t, app, "a", `{}`)
+		done <- status
+	}()
+
+	<-started
+	status, _, _ := post(t, app, "a", `{}`)
+	assert.Equal(t, fiber.StatusConflict, status)
+
+	close(release)
+	assert.Equal(t, fiber.StatusCreated, <-done)
+}
+
+// TestIdempotencyReleasesKey checks that failed requests can be retried with the same key.
+func TestIdempotencyReleasesKey(t *testing.T) {
+	tests := []struct {
+		name string
+		fail fiber.Handler
+	}{
+		{"server error", func(c *fiber.Ctx) error { return apperror.Internal(io.ErrUnexpectedEOF) }},
+		{"panic", func(c *fiber.Ctx) error { panic("boom") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			app := newIdempotentApp(t, func(c *fiber.Ctx) error {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					return tt.fail(c)
+				}
+				return c.SendStatus(fiber.StatusCreated)
+			})
+
+			status, _, _ := post(t, app, "a", `{}`)
+			assert.Equal(t, fiber.StatusInternalServerError, status)
+
+			status, _, _ = post(t, app, "a", `{}`)
+			assert.Equal(t, fiber.StatusCreated, status)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+// TestIdempotencyReportsServerErrors checks that 5xx errors of idempotent routes reach
+// the error report sinks through Recover.
+func TestIdempotencyReportsServerErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	shutdown, err := errorreport.Init(settings.ErrorReport{File: path, RatePerMinute: 10}, "test")
+	require.NoError(t, err)
+
+	app := newIdempotentApp(t, func(c *fiber.Ctx) error {
+		return apperror.Internal(io.ErrUnexpectedEOF)
+	})
+	status, _, _ := post(t, app, "a", `{}`)
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	require.NoError(t, shutdown(context.Background()))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	require.Len(t, lines, 1)
+
+	var event errorreport.Event
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, errorreport.LevelError, event.Level)
+	assert.Equal(t, fiber.StatusInternalServerError, event.Status)
+	assert.Equal(t, "/authors", event.Route)
+}
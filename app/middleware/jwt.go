@@ -1,38 +1,71 @@
 package middleware
 
 import (
-	"os"
+	"errors"
 
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 
 	jwtMiddleware "github.com/gofiber/contrib/jwt"
 )
 
 // JWTProtected func for specify routes group with JWT authentication.
+// The tokens are verified with the secret key of the effective configuration.
 // See: https://github.com/gofiber/contrib/jwt
-func JWTProtected() func(*fiber.Ctx) error {
+func JWTProtected(reloader *settings.Reloader) func(*fiber.Ctx) error {
+	return reloadable(reloader, jwtProtected)
+}
+
+func jwtProtected(cfg *settings.Config) fiber.Handler {
 	// Create config for JWT authentication middleware.
 	config := jwtMiddleware.Config{
-		SigningKey:   jwtMiddleware.SigningKey{Key: []byte(os.Getenv("JWT_SECRET_KEY"))},
+		SigningKey:   jwtMiddleware.SigningKey{JWTAlg: jwtMiddleware.HS256, Key: []byte(cfg.JWT.SecretKey)},
 		ContextKey:   "jwt", // used in private routes
 		ErrorHandler: jwtError,
 	}
 
-	return jwtMiddleware.New(config)
+	header := jwtMiddleware.New(config)
+	if !cfg.AuthCookie.Enabled {
+		return header
+	}
+
+	// Read the HttpOnly cookie only when the Authorization header is missing. A request
+	// with the header, even malformed, never falls back to the cookie: CSRF does not check it.
+	config.TokenLookup = "cookie:" + utils.AccessTokenCookie
+	cookie := jwtMiddleware.New(config)
+	return func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) != "" {
+			return header(c)
+		}
+		return cookie(c)
+	}
 }
 
 func jwtError(c *fiber.Ctx, err error) error {
-	// Return status 401 and failed authentication error.
-	if err.Error() == "Missing or malformed JWT" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   err.Error(),
-		})
+	// Return status 400 and missing token error.
+	if errors.Is(err, jwtMiddleware.ErrJWTMissingOrMalformed) {
+		return apperror.BadRequest("missing or malformed JWT")
 	}
 
 	// Return status 401 and failed authentication error.
-	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-		"success": false,
-		"error":   err.Error(),
-	})
+	return &apperror.Error{Status: fiber.StatusUnauthorized, Code: apperror.CodeUnauthorized, Message: "invalid or expired JWT", Err: err}
+}
+
+// tokenUserID returns the user ID of the JWT validated by JWTProtected, or "".
+func tokenUserID(c *fiber.Ctx) string {
+	token, ok := c.Locals("jwt").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	userID, _ := claims["userId"].(string)
+	return userID
 }
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadConfig loads the configuration of an in-memory database signing with secretKey.
+func loadConfig(t *testing.T, secretKey string) *settings.Config {
+	t.Helper()
+
+	t.Setenv("DB_TYPE", "sqlite")
+	t.Setenv("DB_NAME", ":memory:")
+	t.Setenv("JWT_SECRET_KEY", secretKey)
+	t.Setenv("JWT_REFRESH_KEY", "refresh-"+secretKey)
+	cfg, err := settings.Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func signedToken(t *testing.T, secretKey string) string {
+	t.Helper()
+
+	userID, _ := uuid.NewV4()
+	tokens, err := utils.GenerateNewTokens(settings.JWT{SecretKey: secretKey, ExpireMinutes: 15, RefreshExpireHours: 1}, userID.String(), nil)
+	require.NoError(t, err)
+	return tokens.Access
+}
+
+func TestJWTProtectedReloadsSecretKey(t *testing.T) {
+	reloader := settings.NewReloader(loadConfig(t, "old-key"))
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.ErrorHandler})
+	app.Get("/", JWTProtected(reloader), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	status := func(token string) int {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		if token != "" {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		return res.StatusCode
+	}
+
+	oldToken, newToken := signedToken(t, "old-key"), signedToken(t, "new-key")
+	assert.Equal(t, fiber.StatusOK, status(oldToken))
+	assert.Equal(t, fiber.StatusUnauthorized, status(newToken))
+	assert.Equal(t, fiber.StatusBadRequest, status(""))
+
+	loadConfig(t, "new-key")
+	restart, err := reloader.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, restart, "the JWT keys are reloadable")
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"signed with the rotated key", newToken, fiber.StatusOK},
+		{"signed with the previous key", oldToken, fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, status(tt.token))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/database/cache"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderAPIKey is the header of the API key requests can be limited by.
+const HeaderAPIKey = "X-API-Key"
+
+// setupRateLimit func for limit requests with the configured policies when enabled,
+// counted in memory or in Redis with the redis backend.
+func setupRateLimit(app *fiber.App, reloader *settings.Reloader) {
+	cfg := reloader.Config()
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore(time.Minute)
+	if cfg.RateLimit.Backend == "redis" {
+		client, err := cache.RedisClient(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Rate limit is misconfigured.")
+		}
+		store = ratelimit.NewRedisStore(client)
+	}
+
+	app.Use(reloadable(reloader, func(cfg *settings.Config) fiber.Handler {
+		if !cfg.RateLimit.Enabled {
+			return next
+		}
+
+		// The policies are checked by settings.Load.
+		policies, err := ratelimit.ParsePolicies(cfg.RateLimit.Policies)
+		if err != nil {
+			log.Error().Err(err).Msg("Rate limit is misconfigured.")
+			return next
+		}
+		return RateLimit(store, policies, cfg)
+	}))
+}
+
+// RateLimit func for limit the requests matching a policy, per IP, user or API key.
+// The limit is reported with RateLimit-* headers and exceeded requests get 429 with Retry-After.
+func RateLimit(store ratelimit.Store, policies []ratelimit.Policy, cfg *settings.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		policy, ok := ratelimit.Match(policies, c.Method(), c.Path())
+		if !ok {
+			return c.Next()
+		}
+
+		result, err := store.Allow(c.UserContext(), policy.Route+"|"+rateLimitKey(c, policy.Key, cfg), policy)
+		if err != nil {
+			// Fail open, an unavailable store must not take the API down.
+			logger.Ctx(c.UserContext()).Warn().Err(err).Msg("Rate limit store is unavailable.")
+			return c.Next()
+		}
+
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+		c.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, seconds(time.Duration(policy.Window))))
+
+		if !result.Allowed {
+			retryAfter := seconds(result.RetryAfter)
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			return apperror.TooManyRequests("too many requests, please try again later")
+		}
+
+		return c.Next()
+	}
+}
+
+// rateLimitKey identifies the caller, falling back to the IP when the user or API key is
+// missing or invalid, so a client can't get a new bucket by sending a new key.
+func rateLimitKey(c *fiber.Ctx, key string, cfg *settings.Config) string {
+	switch key {
+	case ratelimit.KeyUser:
+		if claims, err := utils.ParseTokenMetadata(c, cfg.JWT, cfg.AuthCookie); err == nil {
+			return "user:" + claims.UserID.String()
+		}
+	case ratelimit.KeyAPIKey:
+		if sum, ok := validAPIKey(c.Get(HeaderAPIKey), cfg.RateLimit.APIKeys); ok {
+			// Never keep the key itself in the store.
+			return "key:" + hex.EncodeToString(sum[:16])
+		}
+	}
+
+	return "ip:" + c.IP()
+}
+
+// validAPIKey returns the hash of apiKey when it is one of the comma-separated keys.
+func validAPIKey(apiKey, keys string) (sum [sha256.Size]byte, ok bool) {
+	if apiKey == "" {
+		return sum, false
+	}
+
+	sum = sha256.Sum256([]byte(apiKey))
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		expected := sha256.Sum256([]byte(key))
+		if key != "" && subtle.ConstantTimeCompare(sum[:], expected[:]) == 1 {
+			return sum, true
+		}
+	}
+	return sum, false
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
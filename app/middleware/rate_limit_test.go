@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitAPIKey(t *testing.T) {
+	cfg := &settings.Config{RateLimit: settings.RateLimit{APIKeys: "key-a, key-b"}}
+	policies := []ratelimit.Policy{{Route: "/", Limit: 1, Window: ratelimit.Duration(time.Minute), Key: ratelimit.KeyAPIKey, Algorithm: ratelimit.SlidingWindow}}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		statuses []int
+	}{
+		{"each valid key has its bucket", []string{"key-a", "key-b", "key-a"}, []int{fiber.StatusOK, fiber.StatusOK, fiber.StatusTooManyRequests}},
+		{"unknown keys share the IP bucket", []string{"random-1", "random-2"}, []int{fiber.StatusOK, fiber.StatusTooManyRequests}},
+		{"missing key uses the IP bucket", []string{"", "random"}, []int{fiber.StatusOK, fiber.StatusTooManyRequests}},
+		{"valid key is not limited by the IP bucket", []string{"random", "key-a"}, []int{fiber.StatusOK, fiber.StatusOK}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: apperror.ErrorHandler})
+			app.Use(RateLimit(ratelimit.NewMemoryStore(time.Minute), policies, cfg))
+			app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+			for i, key := range tt.keys {
+				req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+				if key != "" {
+					req.Header.Set(HeaderAPIKey, key)
+				}
+				res, err := app.Test(req, -1)
+				require.NoError(t, err)
+				assert.Equal(t, tt.statuses[i], res.StatusCode, "request %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/errorreport"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Recover func for turn panics into 500 problem responses, logging the stack with the
+// request context. Panics and 5xx errors are forwarded to the error report sinks.
+func Recover() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			stack := string(debug.Stack())
+			logger.Ctx(c.UserContext()).Error().
+				Str("panic", fmt.Sprint(recovered)).
+				Str("stack", stack).
+				Msg("Recovered from panic.")
+
+			report(c, errorreport.LevelPanic, fmt.Sprintf("panic: %v", recovered), stack, fiber.StatusInternalServerError)
+			err = apperror.Internal(fmt.Errorf("panic: %v", recovered))
+		}()
+
+		err = c.Next()
+		if err == nil {
+			return nil
+		}
+
+		if status := errorStatus(err); status >= fiber.StatusInternalServerError {
+			report(c, errorreport.LevelError, err.Error(), "", status)
+		}
+
+		return err
+	}
+}
+
+// errorStatus returns the status the error handler responds with for err.
+func errorStatus(err error) int {
+	if appErr, ok := apperror.As(err); ok {
+		return appErr.Status
+	}
+	if fiberErr, ok := err.(*fiber.Error); ok {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// report sends an event of the current request to the error report sinks.
+func report(c *fiber.Ctx, level, message, stack string, status int) {
+	errorreport.Report(errorreport.Event{
+		Level:     level,
+		Message:   message,
+		Stack:     stack,
+		RequestID: logger.RequestID(c.UserContext()),
+		Method:    c.Method(),
+		Route:     c.Route().Path,
+		Path:      c.Path(),
+		Status:    status,
+		UserID:    tokenUserID(c),
+	})
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/gofiber/fiber/v2"
+)
+
+// reloadable func for serve the handler built from the effective configuration,
+// built again each time the configuration is reloaded.
+func reloadable(reloader *settings.Reloader, build func(cfg *settings.Config) fiber.Handler) fiber.Handler {
+	var handler atomic.Value
+	handler.Store(build(reloader.Config()))
+	reloader.OnReload(func(_, cfg *settings.Config) {
+		handler.Store(build(cfg))
+	})
+
+	return func(c *fiber.Ctx) error {
+		return handler.Load().(fiber.Handler)(c)
+	}
+}
+
+// next is the handler of a disabled middleware.
+func next(c *fiber.Ctx) error {
+	return c.Next()
+}
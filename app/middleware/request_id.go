@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// requestIDPattern limits the incoming request IDs kept, so they are safe to log.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID func for accept the incoming X-Request-ID or generate one. The ID is sent
+// back in the response and carried by the user context with a logger tagged with it.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(fiber.HeaderXRequestID, id)
+		c.SetUserContext(logger.WithRequestID(c.UserContext(), id))
+
+		return c.Next()
+	}
+}
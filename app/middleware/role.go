@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/fiber-go-template/app/services"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RoleRequired func for allow only users having one of the given roles.
+// It must be used after JWTProtected.
+func RoleRequired(users services.UserService, roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := tokenUserID(c)
+		if userID == "" {
+			return apperror.Unauthorized("invalid or expired JWT")
+		}
+
+		user, err := users.GetUserByID(c.UserContext(), userID)
+		if err != nil {
+			if appErr, ok := apperror.As(err); ok && appErr.Status == fiber.StatusNotFound {
+				return apperror.Unauthorized("invalid or expired JWT")
+			}
+			return err
+		}
+
+		for _, role := range roles {
+			if user.RoleID == role {
+				return c.Next()
+			}
+		}
+
+		return apperror.Forbidden("you are not allowed to access this resource")
+	}
+}
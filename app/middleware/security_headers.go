@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/fiber-go-template/config/settings"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/helmet"
+)
+
+// setupSecurityHeaders func for add the security headers (HSTS, CSP, X-Content-Type-Options,
+// X-Frame-Options...) unless they are disabled.
+// HSTS is only sent over HTTPS and defaults to one year when STAGE_STATUS is prod.
+func setupSecurityHeaders(app *fiber.App, reloader *settings.Reloader) {
+	app.Use(reloadable(reloader, func(cfg *settings.Config) fiber.Handler {
+		if !cfg.Security.HeadersEnabled {
+			return next
+		}
+
+		return helmet.New(helmet.Config{
+			ContentTypeNosniff:    "nosniff",
+			XFrameOptions:         cfg.Security.FrameOptions,
+			HSTSMaxAge:            cfg.Security.HSTSMaxAgeSeconds,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+			ReferrerPolicy:        "no-referrer",
+		})
+	}))
+}
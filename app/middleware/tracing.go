@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/tracing"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing func for start a server span for each request, continuing the W3C trace
+// context sent by the caller. The span is carried by the user context, and the request
+// logger is tagged with its trace ID.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		ctx, span := tracing.Tracer().Start(ctx, "HTTP "+c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(c.Method()),
+				semconv.HTTPTarget(c.OriginalURL()),
+				semconv.HTTPScheme(c.Protocol()),
+				semconv.NetHostName(c.Hostname()),
+			),
+		)
+		defer span.End()
+
+		if spanContext := span.SpanContext(); spanContext.HasTraceID() {
+			l := logger.Ctx(ctx).With().Str("traceId", spanContext.TraceID().String()).Logger()
+			ctx = l.WithContext(ctx)
+		}
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		span.SetName(c.Method() + " " + c.Route().Path)
+		span.SetAttributes(semconv.HTTPRoute(c.Route().Path), semconv.HTTPStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return err
+	}
+}
+
+// headerCarrier adapts the request headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0)
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
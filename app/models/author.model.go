@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/fiber-go-template/helper/filter"
 	"github.com/gofrs/uuid"
 )
 
@@ -39,6 +40,47 @@ var ColumnMappAuthor = map[string]interface{}{
 	"is_deleted": "is_deleted",
 }
 
+// FieldMappAuthor maps the JSON fields exposed through ?fields= to their columns.
+var FieldMappAuthor = map[string]string{
+	"id":        "id",
+	"name":      "name",
+	"address":   "address",
+	"createdAt": "created_at",
+	"createdBy": "created_by",
+	"updatedAt": "updated_at",
+	"updatedBy": "updated_by",
+	"isDeleted": "is_deleted",
+}
+
+// KeysetMappAuthor lists the non-null columns usable as sort key in cursor pagination.
+var KeysetMappAuthor = map[string]string{
+	"id":        "id",
+	"name":      "name",
+	"createdAt": "created_at",
+}
+
+var FilterMappAuthor = filter.Fields{
+	"id":        {Column: "id", Type: filter.TypeUUID, Operators: filter.EqualityOperators},
+	"name":      {Column: "name", Type: filter.TypeString, Operators: filter.StringOperators},
+	"address":   {Column: "address", Type: filter.TypeString, Operators: filter.StringOperators},
+	"createdAt": {Column: "created_at", Type: filter.TypeTime, Operators: filter.ComparableOperators},
+	"updatedAt": {Column: "updated_at", Type: filter.TypeTime, Operators: filter.ComparableOperators},
+}
+
+var MappingAuthor = Mapping{
+	Table:       tableNameAuthor,
+	Columns:     []string{"id", "name", "address", "created_at", "created_by", "updated_at", "updated_by", "is_deleted"},
+	ListColumns: []string{"id", "name", "address"},
+	ListOrder:   "name asc",
+	Search:      []string{"name", "address"},
+	Sort:        ColumnMappAuthor,
+	DefaultSort: "createdAt",
+	Fields:      FieldMappAuthor,
+	Keyset:      KeysetMappAuthor,
+	Filters:     FilterMappAuthor,
+	DateField:   "createdAt",
+}
+
 func (i *Author) BindFromRequest(req AuthorRequest) {
 	var now = time.Now()
 	if req.ID == uuid.Nil {
@@ -58,5 +100,13 @@ func (i *Author) BindFromRequest(req AuthorRequest) {
 }
 
 func (i *Author) SoftDelete(userID uuid.UUID) {
+	var now = time.Now()
+	i.IsDeleted = true
+	i.UpdatedAt = &now
 	i.UpdatedBy = &userID
 }
+
+func (r *AuthorRequest) SetIdentity(id uuid.UUID, userID uuid.UUID) {
+	r.ID = id
+	r.UserID = userID
+}
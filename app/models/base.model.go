@@ -4,21 +4,78 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+
+	"github.com/fiber-go-template/helper/fieldset"
+	"github.com/fiber-go-template/helper/filter"
+	"github.com/gofrs/uuid"
 )
 
 const RECORD_NOT_FOUND = "record not found"
 
 // StandardRequest is a standard query string request
 type StandardRequest struct {
-	Keyword      string `json:"q" validate:"omitempty"`
-	StartDate    string `json:"startDate" validate:"omitempty"`
-	EndDate      string `json:"endDate" validate:"omitempty"`
-	PageNumber   int    `json:"pageNumber" validate:"omitempty,gte=0"`
-	PageSize     int    `json:"pageSize" validate:"omitempty,gte=0"`
-	SortBy       string `json:"sortBy" validate:"required"`
-	SortType     string `json:"sortType" validate:"required,oneof=asc ASC desc DESC"`
-	Status       string `json:"status" validate:"omitempty"`
-	IgnorePaging bool   `json:"ignorePaging" validate:"omitempty"`
+	Keyword      string `json:"q" query:"q" validate:"omitempty"`
+	StartDate    string `json:"startDate" query:"startDate" validate:"omitempty"`
+	EndDate      string `json:"endDate" query:"endDate" validate:"omitempty"`
+	PageNumber   int    `json:"pageNumber" query:"pageNumber" default:"1" validate:"gte=1"`
+	PageSize     int    `json:"pageSize" query:"pageSize" default:"10" validate:"gte=1"`
+	SortBy       string `json:"sortBy" query:"sortBy" validate:"omitempty"`
+	SortType     string `json:"sortType" query:"sortType" default:"DESC" validate:"required,oneof=asc ASC desc DESC"`
+	Status       string `json:"status" query:"status" validate:"omitempty"`
+	IgnorePaging bool   `json:"ignorePaging" query:"ignorePaging" validate:"omitempty"`
+	PagingMode   string `json:"pagingMode" query:"pagingMode" default:"offset" validate:"oneof=offset cursor"`
+	After        string `json:"after" query:"after" validate:"omitempty"`
+	Before       string `json:"before" query:"before" validate:"omitempty"`
+	SkipCount    bool   `json:"skipCount" query:"skipCount" validate:"omitempty"`
+
+	// Filters holds the parsed filter[field][operator] query parameters.
+	Filters []filter.Condition `json:"-" query:"-"`
+
+	// Fields holds the JSON fields requested with ?fields=, empty for all.
+	Fields fieldset.Fieldset `json:"-" query:"-"`
+}
+
+// Entity is implemented by pointers to models handled by the generic repository and service.
+type Entity[T any, R any] interface {
+	*T
+	TableName() string
+	BindFromRequest(req R)
+	SoftDelete(userID uuid.UUID)
+}
+
+// Payload is implemented by pointers to request bodies handled by the generic controller.
+type Payload[R any] interface {
+	*R
+	SetIdentity(id uuid.UUID, userID uuid.UUID)
+}
+
+// Mapping describes the columns of a model for the generic repository, service and controller.
+type Mapping struct {
+	// Table is the database table name.
+	Table string
+	// Columns lists every selectable column, in select order.
+	Columns []string
+	// ListColumns are the columns selected by GetAll when no fields are requested.
+	ListColumns []string
+	// ListOrder is the ORDER BY clause used by GetAll.
+	ListOrder string
+	// Search are the columns matched by the keyword (q) search.
+	Search []string
+	// Sort maps sortBy values to columns.
+	Sort map[string]interface{}
+	// DefaultSort is the sortBy value used when none is given.
+	DefaultSort string
+	// Fields maps the JSON fields exposed through ?fields= to columns.
+	Fields map[string]string
+	// Keyset maps sortBy values usable for cursor pagination to non-null columns.
+	// The type of the cursor value is read from the same key in Filters.
+	Keyset map[string]string
+	// Filters is the whitelist for filter[field][operator].
+	Filters filter.Fields
+	// DateField is the filter field targeted by startDate and endDate.
+	DateField string
+	// StatusField is the filter field targeted by status, empty when the model has no status.
+	StatusField string
 }
 
 // JSONRaw ...
@@ -0,0 +1,7 @@
+package models
+
+// ConfigReload struct to describe a configuration reload.
+type ConfigReload struct {
+	// RestartRequired lists the changed settings applied on restart.
+	RestartRequired []string `json:"restartRequired"`
+}
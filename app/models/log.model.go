@@ -0,0 +1,6 @@
+package models
+
+// LogLevel struct to describe the log level of the server.
+type LogLevel struct {
+	Level string `json:"level" validate:"required,oneof=trace debug info warn error fatal panic disabled"`
+}
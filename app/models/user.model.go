@@ -22,11 +22,24 @@ type User struct {
 }
 
 type Renew struct {
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 // SignIn struct to describe login user.
 type SignIn struct {
-	Username string `json:"username" validate:"required,username"`
+	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
+
+// Token struct to describe the access and refresh tokens returned to the user.
+type Token struct {
+	AccessToken string `json:"accessToken"`
+	Refresh     string `json:"refresh"`
+}
+
+// AuthResponse struct to describe the sign in and renew response.
+// Token is omitted in cookie auth mode, the tokens are set in HttpOnly cookies.
+type AuthResponse struct {
+	User  User   `json:"user"`
+	Token *Token `json:"token,omitempty"`
+}
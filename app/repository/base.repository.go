@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/database"
+	"github.com/fiber-go-template/helper/fieldset"
+	"github.com/fiber-go-template/helper/filter"
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/gofrs/uuid"
+)
+
+// Repository is the generic data access for a model with soft delete.
+type Repository[T any] interface {
+	ResolveAll(ctx context.Context, req models.StandardRequest) (data pagination.Response[T], err error)
+	GetAll(ctx context.Context, filters []filter.Condition, fields fieldset.Fieldset) (res []T, err error)
+	FindByID(ctx context.Context, id uuid.UUID, fields fieldset.Fieldset) (res T, err error)
+	Create(ctx context.Context, item *T) (err error)
+	Update(ctx context.Context, item *T) (err error)
+}
+
+// BaseRepository implements Repository for any model described by a models.Mapping.
+// Resource repositories embed it and override methods when needed.
+type BaseRepository[T any] struct {
+	DB      database.DBConn
+	Mapping models.Mapping
+}
+
+func NewBaseRepository[T any](db database.DBConn, mapping models.Mapping) *BaseRepository[T] {
+	return &BaseRepository[T]{
+		DB:      db,
+		Mapping: mapping,
+	}
+}
+
+func (r *BaseRepository[T]) ResolveAll(ctx context.Context, req models.StandardRequest) (data pagination.Response[T], err error) {
+	var params []interface{}
+	var query bytes.Buffer
+	query.WriteString(" WHERE " + r.notDeleted() + " ")
+
+	if req.Keyword != "" && len(r.Mapping.Search) > 0 {
+		query.WriteString(" AND ")
+		query.WriteString(" " + r.DB.Dialect().ILike(r.DB.Dialect().Concat(r.Mapping.Search...)) + " ")
+		params = append(params, "%"+req.Keyword+"%")
+	}
+
+	// Apply structured filters
+	filterQuery, filterParams := filter.Build(r.DB.Dialect(), req.Filters)
+	query.WriteString(filterQuery)
+	params = append(params, filterParams...)
+
+	if req.PagingMode == pagination.ModeCursor {
+		return r.resolveAllByCursor(ctx, req, query, params)
+	}
+
+	// Mapping column sorting, id keeps the order stable between pages
+	sortColumn, ok := r.Mapping.Sort[req.SortBy].(string)
+	if !ok {
+		err = pagination.ErrInvalidSort
+		return
+	}
+
+	// Get count data
+	queryCount := r.DB.Dialect().Rebind(r.countQuery() + query.String())
+	var totalData int
+	err = r.DB.Query().QueryRowContext(ctx, queryCount, params...).Scan(&totalData)
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+		return
+	}
+
+	if totalData < 1 {
+		data.Items = make([]T, 0)
+		return
+	}
+
+	query.WriteString("order by " + sortColumn + " " + req.SortType + ", id " + req.SortType + " ")
+
+	// Set Offset, Pagesize / limit
+	offset := (req.PageNumber - 1) * req.PageSize
+	query.WriteString("limit ? offset ? ")
+	params = append(params, req.PageSize)
+	params = append(params, offset)
+
+	// Rebind params to query
+	rawQuery := query.String()
+	rawQuery = r.DB.Dialect().Rebind(r.selectQuery(req.Fields, sortColumn) + rawQuery)
+	rows, err := r.DB.Query().QueryxContext(ctx, rawQuery, params...)
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+		return
+	}
+	defer rows.Close()
+
+	// Mapping to data model
+	data.Items = make([]T, 0)
+	for rows.Next() {
+		var items T
+		err = rows.StructScan(&items)
+		if err != nil {
+			return
+		}
+
+		data.Items = append(data.Items, items)
+	}
+
+	// Generate meta pagination
+	data.Meta = pagination.CreateMeta(totalData, req.PageSize, req.PageNumber)
+
+	return
+}
+
+// resolveAllByCursor pages with a (sort column, id) keyset instead of LIMIT/OFFSET.
+func (r *BaseRepository[T]) resolveAllByCursor(ctx context.Context, req models.StandardRequest, query bytes.Buffer, params []interface{}) (data pagination.Response[T], err error) {
+	sortColumn, ok := r.Mapping.Keyset[req.SortBy]
+	if !ok {
+		err = pagination.ErrInvalidSort
+		return
+	}
+
+	// Get count data, only when requested
+	var totalData *int
+	if !req.SkipCount {
+		var count int
+		queryCount := r.DB.Dialect().Rebind(r.countQuery() + query.String())
+		err = r.DB.Query().QueryRowContext(ctx, queryCount, params...).Scan(&count)
+		if err != nil {
+			logger.ErrorWithStack(ctx, err)
+			return
+		}
+		totalData = &count
+	}
+
+	ascending := strings.EqualFold(req.SortType, "asc")
+	backward := req.Before != ""
+
+	// Walking backward reads the rows in reverse order and flips them afterwards.
+	rawCursor := req.After
+	if backward {
+		rawCursor = req.Before
+		ascending = !ascending
+	}
+
+	if rawCursor != "" {
+		var cursor pagination.Cursor
+		cursor, err = pagination.DecodeCursor(rawCursor, req.SortBy)
+		if err != nil {
+			return
+		}
+
+		var value interface{}
+		value, err = r.cursorValue(req.SortBy, cursor.Value)
+		if err != nil {
+			return
+		}
+
+		operator := "<"
+		if ascending {
+			operator = ">"
+		}
+		query.WriteString(" AND (" + sortColumn + ", id) " + operator + " (?, ?) ")
+		params = append(params, value, cursor.ID)
+	}
+
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+	query.WriteString("order by " + sortColumn + " " + direction + ", id " + direction + " ")
+
+	// Fetch one extra row to know whether another page exists
+	query.WriteString("limit ? ")
+	params = append(params, req.PageSize+1)
+
+	rawQuery := r.DB.Dialect().Rebind(r.selectQuery(req.Fields, sortColumn) + query.String())
+	rows, err := r.DB.Query().QueryxContext(ctx, rawQuery, params...)
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+		return
+	}
+	defer rows.Close()
+
+	data.Items = make([]T, 0)
+	for rows.Next() {
+		var items T
+		err = rows.StructScan(&items)
+		if err != nil {
+			return
+		}
+
+		data.Items = append(data.Items, items)
+	}
+
+	hasMore := len(data.Items) > req.PageSize
+	if hasMore {
+		data.Items = data.Items[:req.PageSize]
+	}
+
+	hasNext, hasPrevious := hasMore, rawCursor != ""
+	if backward {
+		for i, j := 0, len(data.Items)-1; i < j; i, j = i+1, j-1 {
+			data.Items[i], data.Items[j] = data.Items[j], data.Items[i]
+		}
+		// The before cursor is the first row of a page the client already read, so that
+		// page follows this one. When its rows were deleted since, the next page is empty.
+		hasNext, hasPrevious = true, hasMore
+	}
+
+	// Generate meta cursor
+	meta := pagination.CreateCursorMeta(data.Items, req.SortBy, sortColumn, req.PageSize, hasNext, hasPrevious)
+	meta.TotalItems = totalData
+	data.Cursor = &meta
+
+	return
+}
+
+// cursorValue converts the JSON value of a cursor back to the Go type of the sort column.
+// A time must be bound as time.Time, its RFC3339 text doesn't compare with the stored
+// text of SQLite.
+func (r *BaseRepository[T]) cursorValue(sortBy string, value interface{}) (interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	field, ok := r.Mapping.Filters[sortBy]
+	if !ok {
+		return value, nil
+	}
+
+	converted, err := field.Convert(raw)
+	if err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	return converted, nil
+}
+
+func (r *BaseRepository[T]) GetAll(ctx context.Context, filters []filter.Condition, fields fieldset.Fieldset) (res []T, err error) {
+	columns := r.Mapping.ListColumns
+	if selected := fields.Columns(r.Mapping.Fields, "id"); selected != nil {
+		columns = selected
+	}
+
+	err = r.DB.Orm().WithContext(ctx).Table(r.Mapping.Table).
+		Select(columns).
+		Where(r.notDeleted()).
+		Scopes(filter.Scope(r.DB.Dialect(), filters)).
+		Order(r.Mapping.ListOrder).Scan(&res).Error
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+		return
+	}
+	if res == nil {
+		return make([]T, 0), nil
+	}
+	return
+}
+
+func (r *BaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID, fields fieldset.Fieldset) (res T, err error) {
+	query := r.DB.Orm().WithContext(ctx)
+	if selected := fields.Columns(r.Mapping.Fields, "id"); selected != nil {
+		query = query.Select(selected)
+	}
+
+	err = query.Where(r.notDeleted()).First(&res, "id=?", id).Error
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+		return
+	}
+	return
+}
+
+func (r *BaseRepository[T]) Create(ctx context.Context, item *T) (err error) {
+	err = r.DB.Orm().WithContext(ctx).Create(item).Error
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+	}
+	return
+}
+
+func (r *BaseRepository[T]) Update(ctx context.Context, item *T) (err error) {
+	err = r.DB.Orm().WithContext(ctx).Save(item).Error
+	if err != nil {
+		logger.ErrorWithStack(ctx, err)
+	}
+	return
+}
+
+// notDeleted filters out the soft deleted rows.
+func (r *BaseRepository[T]) notDeleted() string {
+	return "coalesce(is_deleted, " + r.DB.Dialect().Bool(false) + ") = " + r.DB.Dialect().Bool(false)
+}
+
+func (r *BaseRepository[T]) countQuery() string {
+	return "select count(id) from " + r.Mapping.Table + " "
+}
+
+// selectQuery narrows the selected columns to the requested fieldset, keeping the
+// id and sort columns needed for ordering and cursors.
+func (r *BaseRepository[T]) selectQuery(fields fieldset.Fieldset, sortColumn string) string {
+	columns := r.Mapping.Columns
+	if selected := fields.Columns(r.Mapping.Fields, "id", sortColumn); selected != nil {
+		columns = selected
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s ", strings.Join(columns, ", "), r.Mapping.Table)
+}
@@ -0,0 +1,230 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/app/repository"
+	"github.com/fiber-go-template/database"
+	"github.com/fiber-go-template/database/dbtest"
+	"github.com/fiber-go-template/helper/fieldset"
+	"github.com/fiber-go-template/helper/filter"
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedAuthors creates "Author 0" to "Author n-1", one second apart in that order.
+func seedAuthors(t *testing.T, repo repository.AuthorRepository, n int) []models.Author {
+	t.Helper()
+
+	// Whole seconds, MySQL DATETIME columns drop the fraction.
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	authors := make([]models.Author, n)
+	for i := range authors {
+		id, err := uuid.NewV4()
+		require.NoError(t, err)
+
+		authors[i] = models.Author{ID: id, Name: fmt.Sprintf("Author %d", i), CreatedAt: start.Add(time.Duration(i) * time.Second)}
+		if i%2 == 0 {
+			address := fmt.Sprintf("Street %d, 50%% off", i)
+			authors[i].Address = &address
+		}
+		require.NoError(t, repo.Create(context.Background(), &authors[i]))
+	}
+	return authors
+}
+
+func names(authors []models.Author) []string {
+	result := make([]string, len(authors))
+	for i, author := range authors {
+		result[i] = author.Name
+	}
+	return result
+}
+
+func listRequest(modify func(req *models.StandardRequest)) models.StandardRequest {
+	req := models.StandardRequest{
+		PageNumber: 1,
+		PageSize:   3,
+		SortBy:     "createdAt",
+		SortType:   "desc",
+		PagingMode: pagination.ModeOffset,
+	}
+	if modify != nil {
+		modify(&req)
+	}
+	return req
+}
+
+func TestResolveAllOffset(t *testing.T) {
+	dbtest.ForEach(t, func(t *testing.T, conn database.DBConn) {
+		repo := repository.NewAuthorRepository(conn)
+		seedAuthors(t, repo, 7)
+
+		contains, err := filter.NewCondition(models.FilterMappAuthor, "address", filter.OpContains, "50%")
+		require.NoError(t, err)
+		startsWith, err := filter.NewCondition(models.FilterMappAuthor, "name", filter.OpStartsWith, "author 1")
+		require.NoError(t, err)
+
+		tests := []struct {
+			name  string
+			req   models.StandardRequest
+			want  []string
+			total int
+		}{
+			{"first page", listRequest(nil), []string{"Author 6", "Author 5", "Author 4"}, 7},
+			{"last page", listRequest(func(req *models.StandardRequest) { req.PageNumber = 3 }), []string{"Author 0"}, 7},
+			{"ascending", listRequest(func(req *models.StandardRequest) { req.SortType = "asc" }), []string{"Author 0", "Author 1", "Author 2"}, 7},
+			{"sort by name", listRequest(func(req *models.StandardRequest) { req.SortBy = "name"; req.SortType = "asc" }), []string{"Author 0", "Author 1", "Author 2"}, 7},
+			{"keyword ignores case", listRequest(func(req *models.StandardRequest) { req.Keyword = "STREET 4" }), []string{"Author 4"}, 1},
+			{"keyword matches any column", listRequest(func(req *models.StandardRequest) { req.Keyword = "author 3" }), []string{"Author 3"}, 1},
+			{"contains escapes wildcards", listRequest(func(req *models.StandardRequest) { req.Filters = []filter.Condition{contains} }), []string{"Author 6", "Author 4", "Author 2"}, 4},
+			{"starts with ignores case", listRequest(func(req *models.StandardRequest) { req.Filters = []filter.Condition{startsWith} }), []string{"Author 1"}, 1},
+			{"no match", listRequest(func(req *models.StandardRequest) { req.Keyword = "nobody" }), []string{}, 0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := repo.ResolveAll(context.Background(), tt.req)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, names(data.Items))
+				if tt.total > 0 {
+					require.NotNil(t, data.Meta)
+					assert.Equal(t, tt.total, data.Meta.TotalItems)
+				}
+			})
+		}
+	})
+}
+
+func TestResolveAllCursor(t *testing.T) {
+	dbtest.ForEach(t, func(t *testing.T, conn database.DBConn) {
+		repo := repository.NewAuthorRepository(conn)
+		seedAuthors(t, repo, 7)
+
+		for _, sortBy := range []string{"createdAt", "name", "id"} {
+			t.Run(sortBy, func(t *testing.T) {
+				all, err := repo.ResolveAll(context.Background(), listRequest(func(req *models.StandardRequest) {
+					req.SortBy = sortBy
+					req.PageSize = 7
+				}))
+				require.NoError(t, err)
+				want := names(all.Items)
+
+				// Walk forward to the end, then backward to the start.
+				var pages [][]string
+				req := listRequest(func(req *models.StandardRequest) {
+					req.SortBy = sortBy
+					req.PagingMode = pagination.ModeCursor
+				})
+				for {
+					data, err := repo.ResolveAll(context.Background(), req)
+					require.NoError(t, err)
+					pages = append(pages, names(data.Items))
+					if !data.Cursor.HasNext {
+						break
+					}
+					require.Less(t, len(pages), 4, "the cursor does not move forward")
+					req.After, req.Before = data.Cursor.Next, ""
+				}
+				assert.Equal(t, [][]string{want[0:3], want[3:6], want[6:7]}, pages)
+
+				data, err := repo.ResolveAll(context.Background(), req)
+				require.NoError(t, err)
+				req.After, req.Before = "", data.Cursor.Previous
+
+				data, err = repo.ResolveAll(context.Background(), req)
+				require.NoError(t, err)
+				assert.Equal(t, want[3:6], names(data.Items))
+				assert.True(t, data.Cursor.HasNext)
+				assert.True(t, data.Cursor.HasPrevious)
+			})
+		}
+	})
+}
+
+func TestResolveAllCursorErrors(t *testing.T) {
+	dbtest.ForEach(t, func(t *testing.T, conn database.DBConn) {
+		repo := repository.NewAuthorRepository(conn)
+		seedAuthors(t, repo, 2)
+
+		nameCursor := pagination.EncodeCursor(pagination.Cursor{SortBy: "name", Value: "Author 1", ID: uuid.Nil.String()})
+		badTime := pagination.EncodeCursor(pagination.Cursor{SortBy: "createdAt", Value: "yesterday", ID: uuid.Nil.String()})
+
+		tests := []struct {
+			name   string
+			modify func(req *models.StandardRequest)
+			want   error
+		}{
+			{"malformed", func(req *models.StandardRequest) { req.After = "%%%" }, pagination.ErrInvalidCursor},
+			{"other sort", func(req *models.StandardRequest) { req.After = nameCursor }, pagination.ErrInvalidCursor},
+			{"bad value", func(req *models.StandardRequest) { req.After = badTime }, pagination.ErrInvalidCursor},
+			{"not a keyset", func(req *models.StandardRequest) { req.SortBy = "address" }, pagination.ErrInvalidSort},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := repo.ResolveAll(context.Background(), listRequest(func(req *models.StandardRequest) {
+					req.PagingMode = pagination.ModeCursor
+					tt.modify(req)
+				}))
+				assert.ErrorIs(t, err, tt.want)
+			})
+		}
+	})
+}
+
+func TestGetAllAndFindByID(t *testing.T) {
+	dbtest.ForEach(t, func(t *testing.T, conn database.DBConn) {
+		repo := repository.NewAuthorRepository(conn)
+		authors := seedAuthors(t, repo, 3)
+
+		deleted := authors[1]
+		deleted.SoftDelete(uuid.Nil)
+		require.NoError(t, repo.Update(context.Background(), &deleted))
+
+		all, err := repo.GetAll(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Author 0", "Author 2"}, names(all))
+
+		in, err := filter.NewCondition(models.FilterMappAuthor, "id", filter.OpIn, authors[0].ID.String()+","+authors[1].ID.String())
+		require.NoError(t, err)
+		filtered, err := repo.GetAll(context.Background(), []filter.Condition{in}, fieldset.Fieldset{"name"})
+		require.NoError(t, err)
+		require.Len(t, filtered, 1)
+		assert.Equal(t, authors[0].ID, filtered[0].ID)
+		assert.Nil(t, filtered[0].Address, "address is not a selected field")
+
+		found, err := repo.FindByID(context.Background(), authors[2].ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Author 2", found.Name)
+		assert.Equal(t, authors[2].Address, found.Address)
+		assert.WithinDuration(t, authors[2].CreatedAt, found.CreatedAt, time.Millisecond)
+
+		_, err = repo.FindByID(context.Background(), deleted.ID, nil)
+		assert.Error(t, err, "soft deleted rows are not found")
+	})
+}
+
+func TestUserRepository(t *testing.T) {
+	dbtest.ForEach(t, func(t *testing.T, conn database.DBConn) {
+		repo := repository.NewUserRepository(conn)
+
+		// The admin user is seeded by the first migration.
+		admin, err := repo.GetUserByUsername(context.Background(), "admin@gmail.com")
+		require.NoError(t, err)
+		assert.Equal(t, "admin", admin.Username)
+
+		byID, err := repo.GetUserByID(context.Background(), admin.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, admin.Email, byID.Email)
+
+		_, err = repo.GetUserByUsername(context.Background(), "nobody@gmail.com")
+		assert.Error(t, err)
+	})
+}
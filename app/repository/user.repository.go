@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/fiber-go-template/app/models"
 	"github.com/fiber-go-template/config/logger"
 	"github.com/fiber-go-template/database"
@@ -25,15 +27,15 @@ func NewUserRepository(db database.DBConn) UserRepository {
 }
 
 type UserRepository interface {
-	GetUserByID(id string) (user models.User, err error)
-	GetUserByUsername(username string) (user models.User, err error)
+	GetUserByID(ctx context.Context, id string) (user models.User, err error)
+	GetUserByUsername(ctx context.Context, username string) (user models.User, err error)
 }
 
 // GetUserByID query for getting one User by given ID.
-func (r *UserRepositoryDB) GetUserByID(id string) (user models.User, err error) {
-	err = r.DB.Query().Get(&user, userQuery.Select+" where id=$1", id)
+func (r *UserRepositoryDB) GetUserByID(ctx context.Context, id string) (user models.User, err error) {
+	err = r.DB.Query().GetContext(ctx, &user, r.DB.Dialect().Rebind(userQuery.Select+" where id=?"), id)
 	if err != nil {
-		logger.ErrorWithStack(err)
+		logger.ErrorWithStack(ctx, err)
 		return
 	}
 
@@ -41,10 +43,10 @@ func (r *UserRepositoryDB) GetUserByID(id string) (user models.User, err error)
 }
 
 // GetUserByEmail query for getting one User by given Username.
-func (r *UserRepositoryDB) GetUserByUsername(username string) (user models.User, err error) {
-	err = r.DB.Query().Get(&user, userQuery.Select+" where email=$1", username)
+func (r *UserRepositoryDB) GetUserByUsername(ctx context.Context, username string) (user models.User, err error) {
+	err = r.DB.Query().GetContext(ctx, &user, r.DB.Dialect().Rebind(userQuery.Select+" where email=?"), username)
 	if err != nil {
-		logger.ErrorWithStack(err)
+		logger.ErrorWithStack(ctx, err)
 		return
 	}
 
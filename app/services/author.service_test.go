@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/app/repository"
+	"github.com/fiber-go-template/app/services"
+	"github.com/fiber-go-template/database"
+	"github.com/fiber-go-template/database/cache"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthorService(t *testing.T, store cache.Store) *services.AuthorServiceImpl {
+	t.Helper()
+
+	conn, err := database.NewMemoryConnection()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Query().Close() })
+
+	return services.NewAuthorService(conn, repository.NewAuthorRepository(conn), store, time.Minute)
+}
+
+// requireStatus checks that err is an apperror with the HTTP status.
+func requireStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	appErr, ok := apperror.As(err)
+	require.True(t, ok, "error %v is not an apperror", err)
+	assert.Equal(t, status, appErr.Status)
+}
+
+func TestAuthorServiceCRUD(t *testing.T) {
+	for name, store := range map[string]cache.Store{"without cache": nil, "with cache": cache.NewLRU(100)} {
+		t.Run(name, func(t *testing.T) {
+			service := newAuthorService(t, store)
+			ctx := context.Background()
+			userID, _ := uuid.NewV4()
+
+			created, err := service.Create(ctx, models.AuthorRequest{Name: "Pramoedya", UserID: userID})
+			require.NoError(t, err)
+			require.NotEqual(t, uuid.Nil, created.ID)
+
+			found, err := service.FindByID(ctx, created.ID, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "Pramoedya", found.Name)
+
+			all, err := service.GetAll(ctx, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, all, 1)
+
+			// Writes invalidate the cached entity and lists.
+			address := "Blora"
+			_, err = service.Update(ctx, created.ID, models.AuthorRequest{ID: created.ID, Name: "Pramoedya Ananta Toer", Address: &address, UserID: userID})
+			require.NoError(t, err)
+
+			found, err = service.FindByID(ctx, created.ID, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "Pramoedya Ananta Toer", found.Name)
+			assert.Equal(t, &address, found.Address)
+
+			_, err = service.Create(ctx, models.AuthorRequest{Name: "Chairil", UserID: userID})
+			require.NoError(t, err)
+			all, err = service.GetAll(ctx, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, all, 2)
+
+			require.NoError(t, service.Delete(ctx, created.ID, userID))
+
+			_, err = service.FindByID(ctx, created.ID, nil)
+			requireStatus(t, err, http.StatusNotFound)
+			all, err = service.GetAll(ctx, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, all, 1)
+		})
+	}
+}
+
+func TestAuthorServiceErrors(t *testing.T) {
+	service := newAuthorService(t, nil)
+	ctx := context.Background()
+	unknown, _ := uuid.NewV4()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		status int
+	}{
+		{"find unknown", func() error { _, err := service.FindByID(ctx, unknown, nil); return err }, http.StatusNotFound},
+		{"update unknown", func() error {
+			_, err := service.Update(ctx, unknown, models.AuthorRequest{ID: unknown, Name: "x"})
+			return err
+		}, http.StatusNotFound},
+		{"delete unknown", func() error { return service.Delete(ctx, unknown, unknown) }, http.StatusNotFound},
+		{"invalid sort", func() error {
+			_, err := service.ResolveAll(ctx, models.StandardRequest{PageNumber: 1, PageSize: 10, SortBy: "password", SortType: "asc", PagingMode: pagination.ModeOffset})
+			return err
+		}, http.StatusBadRequest},
+		{"invalid cursor", func() error {
+			_, err := service.ResolveAll(ctx, models.StandardRequest{PageSize: 10, SortBy: "createdAt", SortType: "asc", PagingMode: pagination.ModeCursor, After: "bad"})
+			return err
+		}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requireStatus(t, tt.call(), tt.status)
+		})
+	}
+}
+
+func TestUserService(t *testing.T) {
+	conn, err := database.NewMemoryConnection()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Query().Close() })
+	service := services.NewUserService(repository.NewUserRepository(conn))
+
+	admin, err := service.GetUserByUsername(context.Background(), "admin@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", admin.RoleID)
+
+	_, err = service.GetUserByID(context.Background(), uuid.Nil.String())
+	requireStatus(t, err, http.StatusNotFound)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/fiber-go-template/app/models"
+	"github.com/fiber-go-template/app/repository"
+	"github.com/fiber-go-template/config/tracing"
+	"github.com/fiber-go-template/database/cache"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/fieldset"
+	"github.com/fiber-go-template/helper/filter"
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// notFoundMessage is the detail of the NotFound error returned for unknown IDs.
+const notFoundMessage = "data with the given ID is not found"
+
+// Service is the generic business layer for a model T created from request R.
+// Errors returned are typed apperror errors.
+type Service[T any, R any] interface {
+	ResolveAll(ctx context.Context, req models.StandardRequest) (data pagination.Response[T], err error)
+	GetAll(ctx context.Context, filters []filter.Condition, fields fieldset.Fieldset) (res []T, err error)
+	FindByID(ctx context.Context, id uuid.UUID, fields fieldset.Fieldset) (res T, err error)
+	Create(ctx context.Context, req R) (res T, err error)
+	Update(ctx context.Context, id uuid.UUID, req R) (res T, err error)
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error)
+}
+
+// CRUDService implements Service on top of a repository.Repository.
+// Resource services embed it and override methods when needed.
+type CRUDService[T any, R any, PT models.Entity[T, R]] struct {
+	Repository repository.Repository[T]
+	// Cache caches FindByID and GetAll when set, writes invalidate it.
+	Cache *cache.Cache
+
+	// name prefixes the span names, e.g. AuthorService.
+	name string
+}
+
+func NewCRUDService[T any, R any, PT models.Entity[T, R]](repository repository.Repository[T]) *CRUDService[T, R, PT] {
+	return &CRUDService[T, R, PT]{
+		Repository: repository,
+		name:       reflect.TypeOf((*T)(nil)).Elem().Name() + "Service",
+	}
+}
+
+// startSpan starts the span of a service method.
+func (s *CRUDService[T, R, PT]) startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, s.name+"."+method)
+}
+
+func (s *CRUDService[T, R, PT]) ResolveAll(ctx context.Context, req models.StandardRequest) (data pagination.Response[T], err error) {
+	ctx, span := s.startSpan(ctx, "ResolveAll")
+	defer func() { tracing.End(span, err) }()
+
+	data, err = s.Repository.ResolveAll(ctx, req)
+	if errors.Is(err, pagination.ErrInvalidCursor) || errors.Is(err, pagination.ErrInvalidSort) {
+		return data, apperror.BadRequest(err.Error())
+	}
+
+	return data, apperror.FromDatabase(err, notFoundMessage)
+}
+
+func (s *CRUDService[T, R, PT]) GetAll(ctx context.Context, filters []filter.Condition, fields fieldset.Fieldset) (res []T, err error) {
+	ctx, span := s.startSpan(ctx, "GetAll")
+	defer func() { tracing.End(span, err) }()
+
+	key := "all:" + filter.Key(filters) + "|" + fields.Key()
+	res, err = cache.GetOrLoad(ctx, s.Cache, key, func(ctx context.Context) ([]T, error) {
+		return s.Repository.GetAll(ctx, filters, fields)
+	})
+	return res, apperror.FromDatabase(err, notFoundMessage)
+}
+
+func (s *CRUDService[T, R, PT]) FindByID(ctx context.Context, id uuid.UUID, fields fieldset.Fieldset) (res T, err error) {
+	ctx, span := s.startSpan(ctx, "FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	key := "id:" + id.String() + ":" + fields.Key()
+	res, err = cache.GetOrLoad(ctx, s.Cache, key, func(ctx context.Context) (T, error) {
+		return s.Repository.FindByID(ctx, id, fields)
+	})
+	return res, apperror.FromDatabase(err, notFoundMessage)
+}
+
+// find reads the full record from the repository, writes never start from a cached copy.
+func (s *CRUDService[T, R, PT]) find(ctx context.Context, id uuid.UUID) (res T, err error) {
+	res, err = s.Repository.FindByID(ctx, id, nil)
+	return res, apperror.FromDatabase(err, notFoundMessage)
+}
+
+func (s *CRUDService[T, R, PT]) Create(ctx context.Context, req R) (res T, err error) {
+	ctx, span := s.startSpan(ctx, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	PT(&res).BindFromRequest(req)
+	err = s.Repository.Create(ctx, &res)
+	if err != nil {
+		var empty T
+		return empty, apperror.FromDatabase(err, notFoundMessage)
+	}
+
+	s.Cache.Invalidate(ctx, "all:")
+	return
+}
+
+func (s *CRUDService[T, R, PT]) Update(ctx context.Context, id uuid.UUID, req R) (res T, err error) {
+	ctx, span := s.startSpan(ctx, "Update")
+	defer func() { tracing.End(span, err) }()
+
+	res, err = s.find(ctx, id)
+	if err != nil {
+		return
+	}
+
+	PT(&res).BindFromRequest(req)
+	err = s.Repository.Update(ctx, &res)
+	if err != nil {
+		return res, apperror.FromDatabase(err, notFoundMessage)
+	}
+
+	s.Cache.Invalidate(ctx, "id:"+id.String()+":", "all:")
+	return res, nil
+}
+
+func (s *CRUDService[T, R, PT]) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (err error) {
+	ctx, span := s.startSpan(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+
+	res, err := s.find(ctx, id)
+	if err != nil {
+		return
+	}
+
+	PT(&res).SoftDelete(userID)
+	err = s.Repository.Update(ctx, &res)
+	if err != nil {
+		return apperror.FromDatabase(err, notFoundMessage)
+	}
+
+	s.Cache.Invalidate(ctx, "id:"+id.String()+":", "all:")
+	return nil
+}
@@ -1,13 +1,17 @@
 package services
 
 import (
+	"context"
+
 	"github.com/fiber-go-template/app/models"
 	"github.com/fiber-go-template/app/repository"
+	"github.com/fiber-go-template/config/tracing"
+	"github.com/fiber-go-template/helper/apperror"
 )
 
 type UserService interface {
-	GetUserByID(id string) (user models.User, err error)
-	GetUserByUsername(username string) (user models.User, err error)
+	GetUserByID(ctx context.Context, id string) (user models.User, err error)
+	GetUserByUsername(ctx context.Context, username string) (user models.User, err error)
 }
 
 type UserServiceImpl struct {
@@ -20,10 +24,18 @@ func NewUserService(repository repository.UserRepository) *UserServiceImpl {
 	}
 }
 
-func (s *UserServiceImpl) GetUserByID(id string) (user models.User, err error) {
-	return s.UserRepository.GetUserByID(id)
+func (s *UserServiceImpl) GetUserByID(ctx context.Context, id string) (user models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID")
+	defer func() { tracing.End(span, err) }()
+
+	user, err = s.UserRepository.GetUserByID(ctx, id)
+	return user, apperror.FromDatabase(err, "user with the given ID is not found")
 }
 
-func (s *UserServiceImpl) GetUserByUsername(username string) (user models.User, err error) {
-	return s.UserRepository.GetUserByUsername(username)
+func (s *UserServiceImpl) GetUserByUsername(ctx context.Context, username string) (user models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByUsername")
+	defer func() { tracing.End(span, err) }()
+
+	user, err = s.UserRepository.GetUserByUsername(ctx, username)
+	return user, apperror.FromDatabase(err, "user with the given email is not found")
 }
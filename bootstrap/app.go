@@ -1,36 +1,95 @@
 package bootstrap
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/fiber-go-template/app/middleware"
 	"github.com/fiber-go-template/config"
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/tracing"
 	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/database"
+	"github.com/fiber-go-template/helper/errorreport"
+	"github.com/fiber-go-template/helper/i18n"
 	"github.com/fiber-go-template/routes"
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
 )
 
 func AppServe() {
+	// Load the configuration, listing every invalid setting before exiting.
+	cfg, err := settings.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	i18n.SetDefault(cfg.DefaultLocale)
+
+	// Reload the reloadable settings on SIGHUP or when the configuration files change.
+	reloader := settings.NewReloader(cfg)
+	reloader.OnReload(func(previous, current *settings.Config) {
+		// Keep a level changed with PUT /api/v1/admin/log-level until the configured one changes.
+		if current.Log.Level != previous.Log.Level {
+			_ = logger.SetLevel(current.Log.Level)
+		}
+	})
+	go reloader.Watch(context.Background())
+
 	// Define Fiber config.
-	config := config.FiberConfig()
+	fiberConfig := config.FiberConfig(cfg)
+
+	// Tracing, flushed when the server stops.
+	shutdownTracing, err := tracing.InitTracing(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error().Err(err).Msg("Tracing is disabled.")
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
+	// Error reporting, flushed when the server stops.
+	shutdownErrorReport, err := errorreport.Init(cfg.ErrorReport, cfg.Stage)
+	if err != nil {
+		log.Error().Err(err).Msg("Error reporting is disabled.")
+	}
+	defer func() { _ = shutdownErrorReport(context.Background()) }()
 
 	// Define a new Fiber app with config.
-	app := fiber.New(config)
+	app := fiber.New(fiberConfig)
 
 	// Middlewares.
-	middleware.FiberMiddleware(app)
+	middleware.FiberMiddleware(app, reloader)
+
+	// Apply the pending migrations, the readiness probe reports a schema left behind.
+	// An in-memory database is migrated when it is opened.
+	if cfg.Database.AutoMigrate && !cfg.Database.InMemory() {
+		if err := database.MigrateUp(cfg); err != nil {
+			log.Error().Err(err).Msg("Migrations are not applied.")
+		}
+	}
 
 	// Dependencies Injection
-	injection := routes.CallDependenciesInjection()
+	injection := routes.CallDependenciesInjection(reloader)
 
 	// Routes.
 	routes.SetupRoutes(app, injection)
+
+	// Metrics, on their own port when METRICS_PORT is set.
+	if cfg.Server.MetricsPort != 0 {
+		metricsApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+		routes.MetricsRoute(metricsApp)
+		go utils.StartMetricsServer(metricsApp, cfg)
+	} else {
+		routes.MetricsRoute(app)
+	}
+
 	routes.NotFoundRoute(app)
 
 	// Start server (with or without graceful shutdown).
-	if os.Getenv("STAGE_STATUS") == "dev" {
-		utils.StartServer(app)
+	if cfg.Stage == "dev" {
+		utils.StartServer(app, cfg)
 	} else {
-		utils.StartServerWithGracefulShutdown(app)
+		utils.StartServerWithGracefulShutdown(app, cfg)
 	}
 }
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/database"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+const migrateUsage = `usage: migrate <command>
+  up [N]         apply all or N pending migrations
+  down [N]       revert the last or the N last migrations
+  status         print the schema version and the pending migrations
+  force VERSION  set the schema version without migrating, to fix a dirty schema`
+
+// Migrate func for run the migrate subcommand with the migrations embedded in the binary.
+func Migrate(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := settings.Load()
+	if err != nil {
+		return err
+	}
+	logger.InitLogger(cfg.Log)
+
+	var n int
+	if len(args) == 2 {
+		if n, err = strconv.Atoi(args[1]); err != nil || n < 0 {
+			return errors.New(migrateUsage)
+		}
+	}
+
+	m, err := database.NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch {
+	case args[0] == "up" && n == 0:
+		err = m.Up()
+	case args[0] == "up":
+		err = m.Steps(n)
+	case args[0] == "down" && len(args) == 1:
+		err = m.Steps(-1)
+	case args[0] == "down" && n > 0:
+		err = m.Steps(-n)
+	case args[0] == "force" && len(args) == 2:
+		err = m.Force(n)
+	case args[0] == "status" && len(args) == 1:
+		return migrateStatus(m, cfg.Database.Type)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no change")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return migrateStatus(m, cfg.Database.Type)
+}
+
+func migrateStatus(m *migrate.Migrate, dbType string) error {
+	versions, err := database.Migrations(dbType)
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return err
+	}
+
+	fmt.Printf("version: %d (dirty: %t)\n", version, dirty)
+	for _, migration := range versions {
+		if migration > version {
+			fmt.Printf("pending: %d\n", migration)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+// Command scaffold generates a new resource following the project conventions:
+// model, repository, service, controller, routes, dependency injection and
+// PostgreSQL/MySQL/SQLite migrations. Existing files are never overwritten.
+//
+// Usage (from the project root):
+//
+//	go run ./cmd/scaffold <name> field:type ...
+//
+// Supported types: string, text, int, int64, float, bool, time, uuid.
+// Append "?" to a type to make the field optional, e.g. address:text?.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	migrationsDir       = "database/migrations"
+	mysqlMigrationsDir  = "database/migrations/mysql"
+	sqliteMigrationsDir = "database/migrations/sqlite"
+	routesFile          = "routes/routes.go"
+	injectionFile       = "routes/injection.go"
+)
+
+var identifier = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// fieldType describes how a scaffold type maps to Go, SQL and the filter whitelist.
+type fieldType struct {
+	Go        string
+	Postgres  string
+	MySQL     string
+	SQLite    string
+	Filter    string
+	Operators string
+	Validate  string
+	Keyset    bool
+	Search    bool
+}
+
+var fieldTypes = map[string]fieldType{
+	"string": {Go: "string", Postgres: "VARCHAR (255)", MySQL: "VARCHAR(255)", SQLite: "VARCHAR (255)", Filter: "filter.TypeString", Operators: "filter.StringOperators", Validate: "lte=255", Keyset: true, Search: true},
+	"text":   {Go: "string", Postgres: "TEXT", MySQL: "TEXT", SQLite: "TEXT", Filter: "filter.TypeString", Operators: "filter.StringOperators", Search: true},
+	"int":    {Go: "int", Postgres: "INT", MySQL: "INT", SQLite: "INTEGER", Filter: "filter.TypeNumber", Operators: "filter.ComparableOperators", Keyset: true},
+	"int64":  {Go: "int64", Postgres: "BIGINT", MySQL: "BIGINT", SQLite: "INTEGER", Filter: "filter.TypeNumber", Operators: "filter.ComparableOperators", Keyset: true},
+	"float":  {Go: "float64", Postgres: "NUMERIC", MySQL: "DOUBLE", SQLite: "REAL", Filter: "filter.TypeNumber", Operators: "filter.ComparableOperators", Keyset: true},
+	"bool":   {Go: "bool", Postgres: "BOOLEAN", MySQL: "BOOLEAN", SQLite: "BOOLEAN", Filter: "filter.TypeBool", Operators: "filter.EqualityOperators"},
+	"time":   {Go: "time.Time", Postgres: "TIMESTAMP WITH TIME ZONE", MySQL: "DATETIME", SQLite: "DATETIME", Filter: "filter.TypeTime", Operators: "filter.ComparableOperators", Keyset: true},
+	"uuid":   {Go: "uuid.UUID", Postgres: "UUID", MySQL: "CHAR(36)", SQLite: "TEXT", Filter: "filter.TypeUUID", Operators: "filter.EqualityOperators"},
+}
+
+// Field is one generated model field.
+type Field struct {
+	fieldType
+	Name     string
+	Column   string
+	JSON     string
+	Optional bool
+}
+
+// GoType returns the Go type of the field, a pointer when optional.
+func (f Field) GoType() string {
+	if f.Optional {
+		return "*" + f.Go
+	}
+	return f.Go
+}
+
+// Validation returns the validate tag of the request field.
+func (f Field) Validation() string {
+	var rules []string
+	if f.Optional {
+		rules = append(rules, "omitempty")
+	} else if f.Go != "bool" {
+		rules = append(rules, "required")
+	}
+	if f.Validate != "" {
+		rules = append(rules, f.Validate)
+	}
+	return strings.Join(rules, ",")
+}
+
+// Resource is the data passed to every template.
+type Resource struct {
+	Module    string
+	Name      string // Go type name, e.g. BlogPost
+	Var       string // Go variable prefix, e.g. blogPost
+	Label     string // human readable, e.g. blog post
+	Table     string // e.g. blog_posts
+	Singular  string // route segment, e.g. blog-post
+	Plural    string // route segment, e.g. blog-posts
+	Fields    []Field
+	Version   string
+	ListOrder string
+}
+
+// SearchColumns are the text columns matched by the keyword search.
+func (r Resource) SearchColumns() []string {
+	var columns []string
+	for _, field := range r.Fields {
+		if field.Search {
+			columns = append(columns, field.Column)
+		}
+	}
+	return columns
+}
+
+// FieldNames lists the filterable JSON field names for swag annotations.
+func (r Resource) FieldNames() string {
+	names := []string{"id"}
+	for _, field := range r.Fields {
+		names = append(names, field.JSON)
+	}
+	return strings.Join(append(names, "createdAt", "updatedAt"), ", ")
+}
+
+func main() {
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") {
+		fmt.Fprintln(os.Stderr, "usage: go run ./cmd/scaffold <name> field:type ...")
+		os.Exit(2)
+	}
+
+	resource, err := newResource(os.Args[1], os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "scaffold:", err)
+		os.Exit(2)
+	}
+
+	if err := generate(resource); err != nil {
+		fmt.Fprintln(os.Stderr, "scaffold:", err)
+		os.Exit(1)
+	}
+}
+
+func newResource(name string, args []string) (resource Resource, err error) {
+	if !identifier.MatchString(name) {
+		return resource, fmt.Errorf("resource name '%s' must be snake_case", name)
+	}
+
+	module, err := modulePath()
+	if err != nil {
+		return resource, err
+	}
+
+	version, err := nextMigrationVersion()
+	if err != nil {
+		return resource, err
+	}
+
+	resource = Resource{
+		Module:    module,
+		Name:      pascal(name),
+		Var:       camel(name),
+		Label:     strings.ReplaceAll(name, "_", " "),
+		Table:     plural(name),
+		Singular:  strings.ReplaceAll(name, "_", "-"),
+		Plural:    strings.ReplaceAll(plural(name), "_", "-"),
+		Version:   version,
+		ListOrder: "created_at desc",
+	}
+
+	seen := map[string]bool{"id": true, "created_at": true, "created_by": true, "updated_at": true, "updated_by": true, "is_deleted": true}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, ":", 2)
+		if len(parts) != 2 || !identifier.MatchString(parts[0]) {
+			return resource, fmt.Errorf("field '%s' must look like name:type", arg)
+		}
+
+		typeName := strings.TrimSuffix(parts[1], "?")
+		definition, ok := fieldTypes[typeName]
+		if !ok {
+			return resource, fmt.Errorf("field '%s' has unsupported type '%s'", parts[0], typeName)
+		}
+
+		if seen[parts[0]] {
+			return resource, fmt.Errorf("field '%s' is declared twice or is reserved", parts[0])
+		}
+		seen[parts[0]] = true
+
+		field := Field{
+			fieldType: definition,
+			Name:      pascal(parts[0]),
+			Column:    parts[0],
+			JSON:      camel(parts[0]),
+			Optional:  strings.HasSuffix(parts[1], "?"),
+		}
+		// Nullable columns break keyset comparisons.
+		field.Keyset = field.Keyset && !field.Optional
+
+		if resource.ListOrder == "created_at desc" && definition.Search && !field.Optional {
+			resource.ListOrder = field.Column + " asc"
+		}
+
+		resource.Fields = append(resource.Fields, field)
+	}
+
+	return resource, nil
+}
+
+func generate(resource Resource) error {
+	files := []struct {
+		path     string
+		template string
+		gofmt    bool
+	}{
+		{"app/models/" + singularFile(resource) + ".model.go", modelTemplate, true},
+		{"app/repository/" + singularFile(resource) + ".repository.go", repositoryTemplate, true},
+		{"app/services/" + singularFile(resource) + ".service.go", serviceTemplate, true},
+		{"app/controllers/" + singularFile(resource) + ".controller.go", controllerTemplate, true},
+	}
+	for _, file := range files {
+		if err := writeFile(file.path, file.template, resource, file.gofmt); err != nil {
+			return err
+		}
+	}
+
+	if err := writeMigrations(resource); err != nil {
+		return err
+	}
+
+	if err := insertAtMarker(routesFile, resource.Name+"Controller", map[string]string{
+		"// scaffold:routes": routesSnippet,
+	}, resource); err != nil {
+		return err
+	}
+
+	return insertAtMarker(injectionFile, resource.Name+"Controller", map[string]string{
+		"// scaffold:injection-fields": injectionFieldSnippet,
+		"// scaffold:injection":        injectionSnippet,
+		"// scaffold:injection-return": injectionReturnSnippet,
+	}, resource)
+}
+
+func writeMigrations(resource Resource) error {
+	suffix := "_create_" + resource.Table + "_table"
+	existing, _ := filepath.Glob(filepath.Join(migrationsDir, "*"+suffix+".up.sql"))
+	if len(existing) > 0 {
+		fmt.Println("skip     migrations (already exist)", existing[0])
+		return nil
+	}
+
+	migrations := []struct {
+		dir      string
+		up, down string
+	}{
+		{migrationsDir, postgresUpTemplate, downTemplate},
+		{mysqlMigrationsDir, mysqlUpTemplate, downTemplate},
+		{sqliteMigrationsDir, sqliteUpTemplate, downTemplate},
+	}
+
+	for _, migration := range migrations {
+		if err := os.MkdirAll(migration.dir, 0o755); err != nil {
+			return err
+		}
+
+		base := filepath.Join(migration.dir, resource.Version+suffix)
+		if err := writeFile(base+".up.sql", migration.up, resource, false); err != nil {
+			return err
+		}
+		if err := writeFile(base+".down.sql", migration.down, resource, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path, text string, resource Resource, gofmt bool) error {
+	if _, err := os.Stat(path); err == nil {
+		fmt.Println("skip    ", path, "(already exists)")
+		return nil
+	}
+
+	content, err := render(text, resource)
+	if err != nil {
+		return fmt.Errorf("render %s: %w", path, err)
+	}
+
+	if gofmt {
+		if content, err = format.Source(content); err != nil {
+			return fmt.Errorf("format %s: %w", path, err)
+		}
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("create  ", path)
+	return nil
+}
+
+// insertAtMarker adds the rendered snippets above their marker comments, unless the
+// file already references the resource.
+func insertAtMarker(path, guard string, snippets map[string]string, resource Resource) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Contains(content, []byte(guard)) {
+		fmt.Println("skip    ", path, "(already wired)")
+		return nil
+	}
+
+	markers := make([]string, 0, len(snippets))
+	for marker := range snippets {
+		markers = append(markers, marker)
+	}
+	// Longest first so "// scaffold:injection" does not match its longer siblings.
+	sort.Slice(markers, func(i, j int) bool { return len(markers[i]) > len(markers[j]) })
+
+	lines := strings.Split(string(content), "\n")
+	done := map[string]bool{}
+	var out []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		for _, marker := range markers {
+			if !done[marker] && strings.HasPrefix(trimmed, marker) && (len(trimmed) == len(marker) || trimmed[len(marker)] == ' ') {
+				snippet, err := render(snippets[marker], resource)
+				if err != nil {
+					return err
+				}
+				out = append(out, strings.TrimRight(string(snippet), "\n"))
+				done[marker] = true
+				break
+			}
+		}
+		out = append(out, line)
+	}
+
+	for _, marker := range markers {
+		if !done[marker] {
+			return fmt.Errorf("%s: marker '%s' not found", path, marker)
+		}
+	}
+
+	formatted, err := format.Source([]byte(strings.Join(out, "\n")))
+	if err != nil {
+		return fmt.Errorf("format %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, formatted, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Println("update  ", path)
+	return nil
+}
+
+func render(text string, resource Resource) ([]byte, error) {
+	tmpl, err := template.New("scaffold").Funcs(template.FuncMap{
+		"upper": strings.ToUpper,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, resource); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func modulePath() (string, error) {
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		return "", fmt.Errorf("run scaffold from the project root: %w", err)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
+	}
+
+	return "", fmt.Errorf("module path not found in go.mod")
+}
+
+func nextMigrationVersion() (string, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return "", err
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		prefix := strings.SplitN(entry.Name(), "_", 2)[0]
+		if version, err := strconv.Atoi(prefix); err == nil && version > latest {
+			latest = version
+		}
+	}
+
+	return fmt.Sprintf("%06d", latest+1), nil
+}
+
+func singularFile(resource Resource) string {
+	return strings.ReplaceAll(resource.Singular, "-", "_")
+}
+
+func pascal(name string) string {
+	var out strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if part == "id" {
+			out.WriteString("ID")
+			continue
+		}
+		out.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return out.String()
+}
+
+func camel(name string) string {
+	value := pascal(name)
+	if strings.HasPrefix(value, "ID") {
+		return "id" + value[2:]
+	}
+	return strings.ToLower(value[:1]) + value[1:]
+}
+
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "y") && !strings.HasSuffix(name, "ay") && !strings.HasSuffix(name, "ey") && !strings.HasSuffix(name, "oy"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+const modelTemplate = `package models
+
+import (
+	"time"
+
+	"{{.Module}}/helper/filter"
+	"github.com/gofrs/uuid"
+)
+
+const tableName{{.Name}} = "{{.Table}}"
+
+type {{.Name}} struct {
+	ID        uuid.UUID  ` + "`" + `db:"id" json:"id" gorm:"column:id"` + "`" + `
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} ` + "`" + `db:"{{.Column}}" json:"{{.JSON}}" gorm:"column:{{.Column}}"` + "`" + `
+{{- end}}
+	CreatedAt time.Time  ` + "`" + `db:"created_at" json:"createdAt" gorm:"column:created_at"` + "`" + `
+	CreatedBy *uuid.UUID ` + "`" + `db:"created_by" json:"createdBy" gorm:"column:created_by"` + "`" + `
+	UpdatedAt *time.Time ` + "`" + `db:"updated_at" json:"updatedAt" gorm:"column:updated_at"` + "`" + `
+	UpdatedBy *uuid.UUID ` + "`" + `db:"updated_by" json:"updatedBy" gorm:"column:updated_by"` + "`" + `
+	IsDeleted bool       ` + "`" + `db:"is_deleted" json:"isDeleted" gorm:"column:is_deleted"` + "`" + `
+}
+
+type {{.Name}}Request struct {
+	ID uuid.UUID ` + "`" + `json:"id"` + "`" + `
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} ` + "`" + `json:"{{.JSON}}"{{with .Validation}} validate:"{{.}}"{{end}}` + "`" + `
+{{- end}}
+	UserID uuid.UUID ` + "`" + `json:"-"` + "`" + `
+}
+
+func (*{{.Name}}) TableName() string {
+	return tableName{{.Name}}
+}
+
+var ColumnMapp{{.Name}} = map[string]interface{}{
+	"id": "id",
+{{- range .Fields}}
+	"{{.JSON}}": "{{.Column}}",
+{{- end}}
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// FieldMapp{{.Name}} maps the JSON fields exposed through ?fields= to their columns.
+var FieldMapp{{.Name}} = map[string]string{
+	"id": "id",
+{{- range .Fields}}
+	"{{.JSON}}": "{{.Column}}",
+{{- end}}
+	"createdAt": "created_at",
+	"createdBy": "created_by",
+	"updatedAt": "updated_at",
+	"updatedBy": "updated_by",
+	"isDeleted": "is_deleted",
+}
+
+// KeysetMapp{{.Name}} lists the non-null columns usable as sort key in cursor pagination.
+var KeysetMapp{{.Name}} = map[string]string{
+	"id": "id",
+{{- range .Fields}}{{if .Keyset}}
+	"{{.JSON}}": "{{.Column}}",
+{{- end}}{{end}}
+	"createdAt": "created_at",
+}
+
+var FilterMapp{{.Name}} = filter.Fields{
+	"id": {Column: "id", Type: filter.TypeUUID, Operators: filter.EqualityOperators},
+{{- range .Fields}}
+	"{{.JSON}}": {Column: "{{.Column}}", Type: {{.Filter}}, Operators: {{.Operators}}},
+{{- end}}
+	"createdAt": {Column: "created_at", Type: filter.TypeTime, Operators: filter.ComparableOperators},
+	"updatedAt": {Column: "updated_at", Type: filter.TypeTime, Operators: filter.ComparableOperators},
+}
+
+var Mapping{{.Name}} = Mapping{
+	Table:       tableName{{.Name}},
+	Columns:     []string{"id", {{range .Fields}}"{{.Column}}", {{end}}"created_at", "created_by", "updated_at", "updated_by", "is_deleted"},
+	ListColumns: []string{"id", {{range .Fields}}"{{.Column}}", {{end}}"created_at"},
+	ListOrder:   "{{.ListOrder}}",
+	Search:      []string{ {{- range $i, $column := .SearchColumns}}{{if $i}}, {{end}}"{{$column}}"{{end -}} },
+	Sort:        ColumnMapp{{.Name}},
+	DefaultSort: "createdAt",
+	Fields:      FieldMapp{{.Name}},
+	Keyset:      KeysetMapp{{.Name}},
+	Filters:     FilterMapp{{.Name}},
+	DateField:   "createdAt",
+}
+
+func (i *{{.Name}}) BindFromRequest(req {{.Name}}Request) {
+	var now = time.Now()
+	if req.ID == uuid.Nil {
+		newID, _ := uuid.NewV4()
+		i.ID = newID
+		i.CreatedAt = now
+		i.CreatedBy = &req.UserID
+		i.UpdatedAt = nil
+	} else {
+		i.ID = req.ID
+		i.UpdatedAt = &now
+		i.UpdatedBy = &req.UserID
+	}
+{{range .Fields}}
+	i.{{.Name}} = req.{{.Name}}
+{{- end}}
+}
+
+func (i *{{.Name}}) SoftDelete(userID uuid.UUID) {
+	var now = time.Now()
+	i.IsDeleted = true
+	i.UpdatedAt = &now
+	i.UpdatedBy = &userID
+}
+
+func (r *{{.Name}}Request) SetIdentity(id uuid.UUID, userID uuid.UUID) {
+	r.ID = id
+	r.UserID = userID
+}
+`
+
+const repositoryTemplate = `package repository
+
+import (
+	"{{.Module}}/app/models"
+	"{{.Module}}/database"
+)
+
+type {{.Name}}Repository interface {
+	Repository[models.{{.Name}}]
+}
+
+type {{.Name}}RepositoryDB struct {
+	*BaseRepository[models.{{.Name}}]
+}
+
+func New{{.Name}}Repository(db database.DBConn) {{.Name}}Repository {
+	return &{{.Name}}RepositoryDB{
+		BaseRepository: NewBaseRepository[models.{{.Name}}](db, models.Mapping{{.Name}}),
+	}
+}
+`
+
+const serviceTemplate = `package services
+
+import (
+	"{{.Module}}/app/models"
+	"{{.Module}}/app/repository"
+	"{{.Module}}/database"
+)
+
+type {{.Name}}Service interface {
+	Service[models.{{.Name}}, models.{{.Name}}Request]
+}
+
+type {{.Name}}ServiceImpl struct {
+	*CRUDService[models.{{.Name}}, models.{{.Name}}Request, *models.{{.Name}}]
+	DB                 database.DBConn
+	{{.Name}}Repository repository.{{.Name}}Repository
+}
+
+func New{{.Name}}Service(db database.DBConn, {{.Var}} repository.{{.Name}}Repository) *{{.Name}}ServiceImpl {
+	return &{{.Name}}ServiceImpl{
+		CRUDService: NewCRUDService[models.{{.Name}}, models.{{.Name}}Request]({{.Var}}),
+		DB:          db,
+		{{.Name}}Repository: {{.Var}},
+	}
+}
+`
+
+const controllerTemplate = `package controllers
+
+import (
+	"{{.Module}}/app/models"
+	"{{.Module}}/app/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type {{.Name}}Controller struct {
+	CRUDController[models.{{.Name}}, models.{{.Name}}Request, *models.{{.Name}}Request]
+	{{.Name}}Service services.{{.Name}}Service
+}
+
+func New{{.Name}}Controller(service services.{{.Name}}Service) {{.Name}}Controller {
+	return {{.Name}}Controller{
+		CRUDController: NewCRUDController[models.{{.Name}}, models.{{.Name}}Request](service, models.Mapping{{.Name}}),
+		{{.Name}}Service: service,
+	}
+}
+
+// ResolveAll list all {{.Name}}.
+// @Summary Get list all {{.Name}}.
+// @Description endpoint get all data with pagination.
+// @Tags {{.Name}}
+// @Produce json
+// @Param q query string false "Keyword search"
+// @Param pageSize query int false "Set pageSize data" default(10)
+// @Param pageNumber query int false "Set page number for pagingMode offset" default(1)
+// @Param sortBy query string false "Set sortBy parameter"
+// @Param sortType query string false "Set sortType with asc or desc" default(DESC)
+// @Param startDate query string false "Filter createdAt from this date (YYYY-MM-DD or RFC3339)"
+// @Param endDate query string false "Filter createdAt until this date (YYYY-MM-DD or RFC3339)"
+// @Param filter[field][operator] query string false "Filter by field, e.g. filter[id][eq]=... Fields: {{.FieldNames}}"
+// @Param pagingMode query string false "Set pagingMode with offset (default) or cursor"
+// @Param after query string false "Cursor of the last item seen, for pagingMode cursor"
+// @Param before query string false "Cursor of the first item seen, for pagingMode cursor"
+// @Param skipCount query bool false "Skip the total count in pagingMode cursor"
+// @Param fields query string false "Comma separated fields to return"
+// @Success 200 {object} response.Base{data=[]models.{{.Name}},meta=response.Meta}
+// @Failure 400 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/{{.Plural}} [get]
+func (h *{{.Name}}Controller) ResolveAll(c *fiber.Ctx) error {
+	return h.CRUDController.ResolveAll(c)
+}
+
+// GetAll func gets all exists {{.Label}}.
+// @Description Get all exists {{.Label}}.
+// @Summary get all exists {{.Label}}
+// @Tags {{.Name}}
+// @Accept json
+// @Produce json
+// @Param startDate query string false "Filter createdAt from this date (YYYY-MM-DD or RFC3339)"
+// @Param endDate query string false "Filter createdAt until this date (YYYY-MM-DD or RFC3339)"
+// @Param filter[field][operator] query string false "Filter by field"
+// @Param fields query string false "Comma separated fields to return"
+// @Success 200 {object} response.Base{data=[]models.{{.Name}}}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/{{.Plural}}/all [get]
+func (h *{{.Name}}Controller) GetAll(c *fiber.Ctx) error {
+	return h.CRUDController.GetAll(c)
+}
+
+// FindByID func gets {{.Label}} by given ID or 404 error.
+// @Description Get {{.Label}} by given ID.
+// @Summary get {{.Label}} by given ID
+// @Tags {{.Name}}
+// @Accept json
+// @Produce json
+// @Param id path string true "{{.Name}} ID"
+// @Param fields query string false "Comma separated fields to return"
+// @Success 200 {object} response.Base{data=models.{{.Name}}}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/{{.Singular}}/{id} [get]
+func (h *{{.Name}}Controller) FindByID(c *fiber.Ctx) error {
+	return h.CRUDController.FindByID(c)
+}
+
+// Create func for creates a new {{.Label}}.
+// @Description Create a new {{.Label}}.
+// @Summary create a new {{.Label}}
+// @Tags {{.Name}}
+// @Accept json
+// @Produce json
+// @Param data body models.{{.Name}}Request true "{{.Name}}"
+// @Param Idempotency-Key header string false "Key replaying the first response of retried requests"
+// @Success 201 {object} response.Base{data=models.{{.Name}}}
+// @Failure 400 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/{{.Singular}} [post]
+func (h *{{.Name}}Controller) Create(c *fiber.Ctx) error {
+	return h.CRUDController.Create(c)
+}
+
+// Update func for updates {{.Label}} by given ID.
+// @Description Update {{.Label}}.
+// @Summary update {{.Label}}
+// @Tags {{.Name}}
+// @Accept json
+// @Produce json
+// @Param id path string true "{{.Name}} ID"
+// @Param data body models.{{.Name}}Request true "{{.Name}}"
+// @Success 200 {object} response.Base{data=models.{{.Name}}}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/{{.Singular}}/{id} [put]
+func (h *{{.Name}}Controller) Update(c *fiber.Ctx) error {
+	return h.CRUDController.Update(c)
+}
+
+// Delete func for delete {{.Label}} by given ID.
+// @Description Delete {{.Label}} by given ID.
+// @Summary delete {{.Label}} by given ID
+// @Tags {{.Name}}
+// @Accept json
+// @Produce json
+// @Param id path string true "{{.Name}} ID"
+// @Success 200 {object} response.Base
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security ApiKeyAuth
+// @Router /v1/{{.Singular}}/{id} [delete]
+func (h *{{.Name}}Controller) Delete(c *fiber.Ctx) error {
+	return h.CRUDController.Delete(c)
+}
+`
+
+const routesSnippet = `	// {{upper .Label}}
+	{{.Var}}Controller := c.{{.Name}}Controller
+	route.Get("/{{.Plural}}", protected, {{.Var}}Controller.ResolveAll)
+	route.Get("/{{.Plural}}/all", protected, {{.Var}}Controller.GetAll)
+	route.Get("/{{.Singular}}/:id", protected, {{.Var}}Controller.FindByID)
+	route.Post("/{{.Singular}}", protected, idempotent, {{.Var}}Controller.Create)
+	route.Put("/{{.Singular}}/:id", protected, {{.Var}}Controller.Update)
+	route.Delete("/{{.Singular}}/:id", protected, {{.Var}}Controller.Delete)
+`
+
+const injectionFieldSnippet = `	{{.Name}}Controller controllers.{{.Name}}Controller
+`
+
+const injectionSnippet = `	// {{.Name}}
+	{{.Var}}Repository := repository.New{{.Name}}Repository(DbConnect)
+	{{.Var}}Service := services.New{{.Name}}Service(DbConnect, {{.Var}}Repository)
+	{{.Var}}Controller := controllers.New{{.Name}}Controller({{.Var}}Service)
+`
+
+const injectionReturnSnippet = `		{{.Name}}Controller: {{.Var}}Controller,
+`
+
+const postgresUpTemplate = `-- Create {{.Table}} table
+CREATE TABLE {{.Table}} (
+    id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+{{- range .Fields}}
+    {{.Column}} {{.Postgres}}{{if not .Optional}} NOT NULL{{end}},
+{{- end}}
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW (),
+    created_by VARCHAR (100),
+    updated_at TIMESTAMP NULL,
+    updated_by VARCHAR (100),
+    is_deleted boolean DEFAULT false
+);
+`
+
+const mysqlUpTemplate = "-- Create {{.Table}} table\n" +
+	"CREATE TABLE `{{.Table}}` (\n" +
+	"    `id` CHAR(36) NOT NULL PRIMARY KEY,\n" +
+	"{{- range .Fields}}\n" +
+	"    `{{.Column}}` {{.MySQL}}{{if not .Optional}} NOT NULL{{end}},\n" +
+	"{{- end}}\n" +
+	"    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,\n" +
+	"    `created_by` VARCHAR(100),\n" +
+	"    `updated_at` DATETIME NULL,\n" +
+	"    `updated_by` VARCHAR(100),\n" +
+	"    `is_deleted` BOOLEAN DEFAULT false\n" +
+	");\n"
+
+const sqliteUpTemplate = `-- Create {{.Table}} table
+CREATE TABLE {{.Table}} (
+    id TEXT NOT NULL PRIMARY KEY,
+{{- range .Fields}}
+    {{.Column}} {{.SQLite}}{{if not .Optional}} NOT NULL{{end}},
+{{- end}}
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR (100),
+    updated_at DATETIME NULL,
+    updated_by VARCHAR (100),
+    is_deleted BOOLEAN DEFAULT 0
+);
+`
+
+const downTemplate = `-- Delete {{.Table}} table
+DROP TABLE IF EXISTS {{.Table}};
+`
@@ -0,0 +1,100 @@
+// Command secrets manages the encrypted secrets file read with SECRETS_FILE.
+// The master key is read from SECRETS_MASTER_KEY or the SECRETS_MASTER_KEY_FILE file.
+//
+// Usage (from the project root):
+//
+//	go run ./cmd/secrets keygen
+//	go run ./cmd/secrets encrypt <secrets.json> <secrets.enc>
+//	go run ./cmd/secrets decrypt <secrets.enc>
+//
+// The plain file is a JSON object of secrets by env name, e.g. {"DB_PASSWORD": "..."}.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fiber-go-template/config/secrets"
+)
+
+const usage = `usage:
+  secrets keygen
+  secrets encrypt <secrets.json> <secrets.enc>
+  secrets decrypt <secrets.enc>`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch {
+	case args[0] == "keygen" && len(args) == 1:
+		key, err := secrets.NewKey()
+		if err != nil {
+			return err
+		}
+		fmt.Println(key)
+		return nil
+
+	case args[0] == "encrypt" && len(args) == 3:
+		key, err := masterKey()
+		if err != nil {
+			return err
+		}
+		plaintext, err := os.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		var values map[string]string
+		if err := json.Unmarshal(plaintext, &values); err != nil {
+			return fmt.Errorf("%s must be a JSON object of strings: %w", args[1], err)
+		}
+		encrypted, err := secrets.Encrypt(key, plaintext)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(args[2], encrypted, 0o600)
+
+	case args[0] == "decrypt" && len(args) == 2:
+		key, err := masterKey()
+		if err != nil {
+			return err
+		}
+		encrypted, err := os.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		plaintext, err := secrets.Decrypt(key, encrypted)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(plaintext)
+		return err
+	}
+
+	return errors.New(usage)
+}
+
+func masterKey() ([]byte, error) {
+	encoded := os.Getenv("SECRETS_MASTER_KEY")
+	if path := os.Getenv("SECRETS_MASTER_KEY_FILE"); encoded == "" && path != "" {
+		var err error
+		if encoded, err = secrets.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if encoded == "" {
+		return nil, errors.New("SECRETS_MASTER_KEY or SECRETS_MASTER_KEY_FILE is required")
+	}
+
+	return secrets.ParseKey(encoded)
+}
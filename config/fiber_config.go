@@ -1,26 +1,23 @@
 package config
 
 import (
-	"os"
-	"strconv"
 	"time"
 
 	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/helper/apperror"
 	"github.com/gofiber/fiber/v2"
 )
 
 // FiberConfig func for configuration Fiber app.
 // See: https://docs.gofiber.io/api/fiber#config
-func FiberConfig() fiber.Config {
-	// Define server settings.
-	readTimeoutSecondsCount, _ := strconv.Atoi(os.Getenv("SERVER_READ_TIMEOUT"))
-
+func FiberConfig(cfg *settings.Config) fiber.Config {
 	// Init Logger
-	logger.InitLogger()
-	logger.SetLogLevel()
+	logger.InitLogger(cfg.Log)
 
 	// Return Fiber configuration.
 	return fiber.Config{
-		ReadTimeout: time.Second * time.Duration(readTimeoutSecondsCount),
+		ReadTimeout:  time.Second * time.Duration(cfg.Server.ReadTimeoutSeconds),
+		ErrorHandler: apperror.ErrorHandler,
 	}
 }
@@ -1,39 +1,105 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/fiber-go-template/config/settings"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// InitLogger initializes the logger
-func InitLogger() {
+// InitLogger initializes the logger at the configured level.
+// The format is console or json on stdout; File adds a JSON log file rotated by
+// size and age, see fileWriter.
+func InitLogger(cfg settings.Log) {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	zerolog.SetGlobalLevel(zerolog.TraceLevel)
 
-	output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
-	// format the output as needed here
+	var output io.Writer = os.Stdout
+	if !strings.EqualFold(cfg.Format, "json") {
+		output = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
+	}
+
+	if cfg.File != "" {
+		output = zerolog.MultiLevelWriter(output, fileWriter(cfg))
+	}
 
-	log.Logger = log.Output(output)
+	log.Logger = zerolog.New(output).With().Timestamp().Logger()
+	if err := SetLevel(cfg.Level); err != nil {
+		log.Warn().Err(err).Str("loglevel", cfg.Level).Msg("Invalid log level, using info.")
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	}
 	log.Trace().Msg("Zerolog initialized.")
 }
 
-// ErrorWithStack logs and error and its stack trace with custom formatting.
-func ErrorWithStack(err error) {
-	log.Error().Msgf("%+v", errors.WithStack(err))
+// fileWriter returns a writer to the log file, rotated by size (MB) and age (days).
+func fileWriter(cfg settings.Log) io.Writer {
+	return &lumberjack.Logger{
+		Filename:   cfg.File,
+		MaxSize:    cfg.FileMaxSizeMB,
+		MaxAge:     cfg.FileMaxAgeDays,
+		MaxBackups: cfg.FileMaxBackups,
+		Compress:   cfg.FileCompress,
+	}
+}
+
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID and a logger tagged with it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	l := log.With().Str("requestId", requestID).Logger()
+	return l.WithContext(ctx)
+}
+
+// RequestID returns the request ID carried by ctx, or "".
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Ctx returns the request-scoped logger carried by ctx, or the global logger.
+func Ctx(ctx context.Context) *zerolog.Logger {
+	if ctx != nil {
+		if l := zerolog.Ctx(ctx); l.GetLevel() != zerolog.Disabled {
+			return l
+		}
+	}
+	return &log.Logger
+}
+
+// ErrorWithStack logs and error and its stack trace with custom formatting,
+// using the request-scoped logger of ctx.
+func ErrorWithStack(ctx context.Context, err error) {
+	Ctx(ctx).Error().Msgf("%+v", errors.WithStack(err))
+}
+
+// Level returns the current log level.
+func Level() string {
+	return zerolog.GlobalLevel().String()
 }
 
-// SetLogLevel sets the desired log level specified in env var.
-func SetLogLevel() {
-	level, err := zerolog.ParseLevel("info")
+// SetLevel changes the log level at runtime.
+func SetLevel(level string) error {
+	parsed, err := zerolog.ParseLevel(strings.ToLower(level))
 	if err != nil {
-		level = zerolog.TraceLevel
-		log.Trace().Str("loglevel", level.String()).Msg("Environment has no log level set up, using default.")
-	} else {
-		log.Trace().Str("loglevel", level.String()).Msg("Desired log level detected.")
+		return err
 	}
-	zerolog.SetGlobalLevel(level)
+	if parsed == zerolog.NoLevel {
+		return fmt.Errorf("unknown level %q", level)
+	}
+
+	zerolog.SetGlobalLevel(parsed)
+	log.Info().Str("loglevel", parsed.String()).Msg("Log level changed.")
+	return nil
 }
@@ -0,0 +1,114 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySize is the size of the master key, AES-256.
+const KeySize = 32
+
+// EncryptedFile is a Provider reading a JSON object of secrets encrypted with
+// AES-256-GCM by a master key, see Encrypt.
+type EncryptedFile struct {
+	Path string
+	Key  []byte
+}
+
+func NewEncryptedFile(path string, key []byte) *EncryptedFile {
+	return &EncryptedFile{
+		Path: path,
+		Key:  key,
+	}
+}
+
+func (f *EncryptedFile) Name() string {
+	return "secrets file " + f.Path
+}
+
+func (f *EncryptedFile) Load(_ context.Context) (map[string]string, error) {
+	raw, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := Decrypt(f.Key, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+// ParseKey decodes a base64 master key.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(key) != KeySize {
+		return nil, fmt.Errorf("the master key must be %d bytes encoded in base64", KeySize)
+	}
+	return key, nil
+}
+
+// NewKey returns a random base64 master key.
+func NewKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt seals plaintext with key, the result is base64 text of the nonce followed by the ciphertext.
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plaintext, nil)
+	return []byte(base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// Decrypt opens the result of Encrypt.
+func Decrypt(key, encrypted []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encrypted)))
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("malformed secrets file")
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("the secrets file does not match the master key")
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
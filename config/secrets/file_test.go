@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKey returns a random decoded master key.
+func newKey(t *testing.T) []byte {
+	t.Helper()
+
+	encoded, err := NewKey()
+	require.NoError(t, err)
+	key, err := ParseKey(encoded)
+	require.NoError(t, err)
+	return key
+}
+
+func TestEncryptedFileLoad(t *testing.T) {
+	key := newKey(t)
+	encrypted, err := Encrypt(key, []byte(`{"DB_PASSWORD":"s3cret","JWT_SECRET_KEY":"jwt"}`))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	require.NoError(t, os.WriteFile(path, encrypted, 0o600))
+
+	tests := []struct {
+		name    string
+		path    string
+		key     []byte
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "round trip",
+			path: path,
+			key:  key,
+			want: map[string]string{"DB_PASSWORD": "s3cret", "JWT_SECRET_KEY": "jwt"},
+		},
+		{name: "wrong key", path: path, key: newKey(t), wantErr: "does not match the master key"},
+		{name: "invalid key size", path: path, key: key[:10], wantErr: "invalid key size"},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.enc"), key: key, wantErr: "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets, err := NewEncryptedFile(tt.path, tt.key).Load(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, secrets)
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	key := newKey(t)
+	encrypted, err := Encrypt(key, []byte("plain"))
+	require.NoError(t, err)
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encrypted)))
+	require.NoError(t, err)
+	sealed[len(sealed)-1] ^= 1
+	tampered := []byte(base64.StdEncoding.EncodeToString(sealed))
+
+	tests := []struct {
+		name      string
+		encrypted []byte
+		want      string
+		wantErr   string
+	}{
+		{"round trip", encrypted, "plain", ""},
+		{"not base64", []byte("%%%"), "", "malformed secrets file"},
+		{"too short", []byte("AAAA"), "", "malformed secrets file"},
+		{"tampered", tampered, "", "does not match the master key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, err := Decrypt(key, tt.encrypted)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(plaintext))
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{"valid", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\n", false},
+		{"too short", "AAAA", true},
+		{"not base64", "%%%", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKey(tt.encoded)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, key, KeySize)
+		})
+	}
+}
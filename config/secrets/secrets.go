@@ -0,0 +1,41 @@
+// Package secrets reads credentials from secret stores instead of plain environment variables.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Provider returns secrets by name, e.g. DB_PASSWORD.
+type Provider interface {
+	// Name identifies the provider in errors.
+	Name() string
+	Load(ctx context.Context) (map[string]string, error)
+}
+
+// Lookup loads every provider, later providers override earlier ones.
+func Lookup(ctx context.Context, providers ...Provider) (map[string]string, error) {
+	secrets := map[string]string{}
+	for _, provider := range providers {
+		values, err := provider.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", provider.Name(), err)
+		}
+		for name, value := range values {
+			secrets[name] = value
+		}
+	}
+	return secrets, nil
+}
+
+// ReadFile returns the secret stored in path, like the Docker and Kubernetes secrets
+// mounted as files, without its trailing newline.
+func ReadFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(raw), "\r\n"), nil
+}
@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticProvider returns its secrets, or err.
+type staticProvider struct {
+	secrets map[string]string
+	err     error
+}
+
+func (p staticProvider) Name() string { return "static" }
+
+func (p staticProvider) Load(_ context.Context) (map[string]string, error) {
+	return p.secrets, p.err
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []Provider
+		want      map[string]string
+		wantErr   string
+	}{
+		{name: "no provider", want: map[string]string{}},
+		{
+			name: "later providers override",
+			providers: []Provider{
+				staticProvider{secrets: map[string]string{"DB_PASSWORD": "file", "REDIS_PASSWORD": "redis"}},
+				staticProvider{secrets: map[string]string{"DB_PASSWORD": "vault"}},
+			},
+			want: map[string]string{"DB_PASSWORD": "vault", "REDIS_PASSWORD": "redis"},
+		},
+		{
+			name: "provider error",
+			providers: []Provider{
+				staticProvider{secrets: map[string]string{"DB_PASSWORD": "file"}},
+				staticProvider{err: errors.New("sealed")},
+			},
+			wantErr: "static: sealed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets, err := Lookup(context.Background(), tt.providers...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, secrets)
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no newline", "s3cret", "s3cret"},
+		{"trailing newline", "s3cret\n", "s3cret"},
+		{"windows newline", "s3cret\r\n", "s3cret"},
+		{"inner spaces are kept", " s3 cret \n", " s3 cret "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "secret")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			value, err := ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	_, err := ReadFile(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Vault is a Provider reading a secret of the HashiCorp Vault KV version 2 engine,
+// the keys of the secret are the secret names.
+type Vault struct {
+	Address string
+	Token   string
+	// Mount is the path of the KV engine, e.g. secret.
+	Mount string
+	// Path is the path of the secret in the engine, e.g. fiber-go-template.
+	Path   string
+	Client *http.Client
+}
+
+func NewVault(address, token, mount, path string) *Vault {
+	return &Vault{
+		Address: strings.TrimRight(address, "/"),
+		Token:   token,
+		Mount:   strings.Trim(mount, "/"),
+		Path:    strings.Trim(path, "/"),
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (v *Vault) Name() string {
+	return "vault " + v.Mount + "/" + v.Path
+}
+
+// vaultResponse is the response of GET /v1/<mount>/data/<path>.
+type vaultResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (v *Vault) Load(ctx context.Context) (map[string]string, error) {
+	endpoint := v.Address + "/v1/" + url.PathEscape(v.Mount) + "/data/" + escapePath(v.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d %s", resp.StatusCode, strings.Join(body.Errors, ", "))
+	}
+
+	secrets := make(map[string]string, len(body.Data.Data))
+	for name, value := range body.Data.Data {
+		if text, ok := value.(string); ok {
+			secrets[name] = text
+		} else {
+			secrets[name] = fmt.Sprint(value)
+		}
+	}
+	return secrets, nil
+}
+
+// escapePath escapes each segment of a secret path.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,92 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		path    string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "reads the KV v2 secret",
+			token: "root",
+			path:  "fiber-go-template",
+			want:  map[string]string{"DB_PASSWORD": "s3cret", "REDIS_DB": "2"},
+		},
+		{name: "missing secret", token: "root", path: "missing", wantErr: "status 404"},
+		{name: "invalid token", token: "wrong", path: "fiber-go-template", wantErr: "status 403 permission denied"},
+		{name: "server error", token: "root", path: "broken", wantErr: "status 500"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/fiber-go-template":
+			_, _ = w.Write([]byte(`{"data":{"data":{"DB_PASSWORD":"s3cret","REDIS_DB":2},"metadata":{"version":3}}}`))
+		case "/v1/secret/data/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`internal error`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := NewVault(server.URL+"/", tt.token, "/secret/", tt.path)
+			secrets, err := vault.Load(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, secrets)
+		})
+	}
+}
+
+func TestVaultLoadInvalidResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := NewVault(server.URL, "root", "secret", "app").Load(context.Background())
+	assert.ErrorContains(t, err, "invalid response")
+}
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"app", "app"},
+		{"team/app", "team/app"},
+		{"team/my app", "team/my%20app"},
+		{"a?b/c#d", "a%3Fb/c%23d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, escapePath(tt.path))
+		})
+	}
+}
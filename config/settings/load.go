@@ -0,0 +1,237 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fiber-go-template/config/secrets"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the YAML configuration file read when CONFIG_FILE is not set, if it exists.
+const DefaultFile = "config.yaml"
+
+// file is the layout of the YAML configuration file: the settings of every stage
+// and, under profiles, the settings overriding them for one STAGE_STATUS.
+type file struct {
+	Stage    string               `yaml:"stage"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// Load func for load the configuration from, by increasing priority:
+//   - the defaults, see the default and prod tags of Config
+//   - the YAML file of CONFIG_FILE (default config.yaml, optional), then its profile of STAGE_STATUS
+//   - the secret providers, for the settings tagged secret, see applySecrets
+//   - the .env file, then the environment; a setting tagged secret is also read from
+//     the file named by its env name suffixed with _FILE, e.g. DB_PASSWORD_FILE
+//
+// Every invalid setting is reported at once in the returned *Error.
+func Load() (*Config, error) {
+	if err := loadDotEnv(); err != nil {
+		return nil, err
+	}
+
+	raw, err := readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var layout file
+	if err := yaml.Unmarshal(raw, &layout); err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+
+	// The stage picks the defaults and the profile, so it is resolved first.
+	stage := os.Getenv("STAGE_STATUS")
+	if stage == "" {
+		stage = layout.Stage
+	}
+	if stage == "" {
+		stage = "dev"
+	}
+
+	cfg := &Config{}
+	problems := &Error{}
+	applyDefaults(reflect.ValueOf(cfg).Elem(), stage, problems)
+
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	if profile, ok := layout.Profiles[stage]; ok {
+		if err := profile.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("failed to read the %s profile: %w", stage, err)
+		}
+	}
+
+	applyEnv(reflect.ValueOf(cfg).Elem(), problems)
+	applySecrets(cfg, problems)
+	validate(cfg, problems)
+
+	if len(problems.Problems) > 0 {
+		return cfg, problems
+	}
+	return cfg, nil
+}
+
+// DotEnvFile is the .env file read by Load.
+const DotEnvFile = ".env"
+
+var (
+	// processEnv holds the names set in the environment of the process, never overridden by .env.
+	processEnv     map[string]bool
+	processEnvOnce sync.Once
+	// dotEnvNames holds the names Load last set from .env.
+	dotEnvNames = map[string]bool{}
+)
+
+// loadDotEnv sets the variables of .env missing from the environment of the process.
+// On reload, the variables removed from .env are unset.
+func loadDotEnv() error {
+	processEnvOnce.Do(func() {
+		processEnv = map[string]bool{}
+		for _, pair := range os.Environ() {
+			processEnv[strings.SplitN(pair, "=", 2)[0]] = true
+		}
+	})
+
+	values, err := godotenv.Read(DotEnvFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read .env: %w", err)
+	}
+
+	for name := range dotEnvNames {
+		if _, ok := values[name]; !ok {
+			_ = os.Unsetenv(name)
+			delete(dotEnvNames, name)
+		}
+	}
+	for name, value := range values {
+		if processEnv[name] {
+			continue
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+		dotEnvNames[name] = true
+	}
+	return nil
+}
+
+// File returns the path of the YAML configuration file.
+func File() string {
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		return path
+	}
+	return DefaultFile
+}
+
+func readFile() ([]byte, error) {
+	raw, err := os.ReadFile(File())
+	if errors.Is(err, fs.ErrNotExist) && os.Getenv("CONFIG_FILE") == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	return raw, nil
+}
+
+// applyDefaults sets the default tag of every setting, or its prod tag in the prod stage.
+func applyDefaults(value reflect.Value, stage string, problems *Error) {
+	eachSetting(value, func(field reflect.StructField, setting reflect.Value) {
+		def, ok := field.Tag.Lookup("default")
+		if prod, hasProd := field.Tag.Lookup("prod"); hasProd && stage == "prod" {
+			def, ok = prod, true
+		}
+		if !ok {
+			return
+		}
+
+		if err := set(setting, def); err != nil {
+			problems.add(field.Tag.Get("env"), "invalid default %q: %v", def, err)
+		}
+	})
+}
+
+// applyEnv sets the settings found in the environment, empty values are ignored.
+func applyEnv(value reflect.Value, problems *Error) {
+	eachSetting(value, func(field reflect.StructField, setting reflect.Value) {
+		name := field.Tag.Get("env")
+		raw, err := lookupEnv(field)
+		if err != nil {
+			problems.add(name+"_FILE", "%v", err)
+			return
+		}
+		if raw == "" {
+			return
+		}
+
+		if err := set(setting, raw); err != nil {
+			problems.add(name, "must be a valid %s, got %q", setting.Kind(), raw)
+		}
+	})
+}
+
+// lookupEnv returns the env value of a setting, for a setting tagged secret
+// the content of the NAME_FILE file when NAME is empty.
+func lookupEnv(field reflect.StructField) (string, error) {
+	name := field.Tag.Get("env")
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw != "" || field.Tag.Get("secret") != "true" {
+		return raw, nil
+	}
+
+	if path := os.Getenv(name + "_FILE"); path != "" {
+		return secrets.ReadFile(path)
+	}
+	return "", nil
+}
+
+// eachSetting calls fn for every field with an env tag, nested structs included.
+func eachSetting(value reflect.Value, fn func(field reflect.StructField, setting reflect.Value)) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			eachSetting(value.Field(i), fn)
+			continue
+		}
+		if _, ok := field.Tag.Lookup("env"); ok {
+			fn(field, value.Field(i))
+		}
+	}
+}
+
+func set(setting reflect.Value, raw string) error {
+	switch setting.Kind() {
+	case reflect.String:
+		setting.SetString(raw)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		setting.SetInt(int64(parsed))
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		setting.SetFloat(parsed)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		setting.SetBool(parsed)
+	default:
+		return fmt.Errorf("unsupported setting type %s", setting.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package settings
+
+import (
+	"reflect"
+	"strings"
+)
+
+// redacted replaces the value of the settings tagged secret.
+const redacted = "[REDACTED]"
+
+// Redacted returns the settings by YAML key, with the secrets redacted.
+func (c *Config) Redacted() map[string]interface{} {
+	return redact(reflect.ValueOf(c).Elem())
+}
+
+func redact(value reflect.Value) map[string]interface{} {
+	settings := map[string]interface{}{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			settings[name] = redact(value.Field(i))
+		case field.Tag.Get("secret") == "true" && !value.Field(i).IsZero():
+			settings[name] = redacted
+		default:
+			settings[name] = value.Field(i).Interface()
+		}
+	}
+	return settings
+}
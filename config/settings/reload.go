@@ -0,0 +1,172 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultWatchInterval is how often the configuration files are checked for changes.
+const defaultWatchInterval = 5 * time.Second
+
+// Reloader holds the effective configuration. Reload applies the settings tagged
+// reload of a newly loaded configuration, the others need a restart.
+type Reloader struct {
+	current   atomic.Pointer[Config]
+	mu        sync.Mutex
+	listeners []func(previous, current *Config)
+}
+
+func NewReloader(cfg *Config) *Reloader {
+	reloader := &Reloader{}
+	reloader.current.Store(cfg)
+	return reloader
+}
+
+// Config returns the effective configuration, never modify it.
+func (r *Reloader) Config() *Config {
+	return r.current.Load()
+}
+
+// OnReload registers fn, called with the previous and the new configuration after each reload.
+func (r *Reloader) OnReload(fn func(previous, current *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, fn)
+}
+
+// Reload loads the configuration again and swaps in its reloadable settings.
+// An invalid configuration is rejected and the effective one stays active.
+// The env names of the changed settings needing a restart are returned.
+func (r *Reloader) Reload() (restart []string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	previous := r.current.Load()
+	next := *previous
+	restart = merge(reflect.ValueOf(&next).Elem(), reflect.ValueOf(loaded).Elem())
+
+	r.current.Store(&next)
+	for _, fn := range r.listeners {
+		fn(previous, &next)
+	}
+
+	return restart, nil
+}
+
+// Watch func for reload the configuration on SIGHUP and when the configuration
+// file or .env changes, checked every CONFIG_WATCH_INTERVAL_SECONDS (default 5, 0 to only
+// reload on SIGHUP). With a secret provider, it also reloads every Secrets.RefreshSeconds
+// to pick up rotated secrets. It returns when ctx is done.
+func (r *Reloader) Watch(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var tick <-chan time.Time
+	if interval := watchInterval(); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var refresh <-chan time.Time
+	if cfg := r.Config().Secrets; cfg.Enabled() && cfg.RefreshSeconds > 0 {
+		ticker := time.NewTicker(time.Duration(cfg.RefreshSeconds) * time.Second)
+		defer ticker.Stop()
+		refresh = ticker.C
+	}
+
+	modified := modTimes()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			modified = modTimes()
+			r.reload("signal")
+		case <-tick:
+			if current := modTimes(); current != modified {
+				modified = current
+				r.reload("file")
+			}
+		case <-refresh:
+			r.reload("secrets")
+		}
+	}
+}
+
+func (r *Reloader) reload(trigger string) {
+	restart, err := r.Reload()
+	if err != nil {
+		log.Error().Err(err).Str("trigger", trigger).Msg("Configuration reload rejected, the current configuration stays active.")
+		return
+	}
+
+	log.Info().Str("trigger", trigger).Msg("Configuration reloaded.")
+	if len(restart) > 0 {
+		log.Warn().Strs("settings", restart).Msg("Changed settings are applied on restart.")
+	}
+}
+
+// merge copies the settings tagged reload from loaded into current, returning the
+// env names of the other settings that differ.
+func merge(current, loaded reflect.Value) (restart []string) {
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			restart = append(restart, merge(current.Field(i), loaded.Field(i))...)
+			continue
+		}
+
+		if current.Field(i).Interface() == loaded.Field(i).Interface() {
+			continue
+		}
+		if field.Tag.Get("reload") == "true" {
+			current.Field(i).Set(loaded.Field(i))
+		} else {
+			restart = append(restart, field.Tag.Get("env"))
+		}
+	}
+	return restart
+}
+
+// modTimes identifies the versions of the configuration files.
+func modTimes() string {
+	var version string
+	for _, path := range []string{File(), DotEnvFile} {
+		if info, err := os.Stat(path); err == nil {
+			version += fmt.Sprintf("%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	return version
+}
+
+func watchInterval() time.Duration {
+	raw := os.Getenv("CONFIG_WATCH_INTERVAL_SECONDS")
+	if raw == "" {
+		return defaultWatchInterval
+	}
+
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		log.Warn().Str("value", raw).Msg("Invalid CONFIG_WATCH_INTERVAL_SECONDS, using 5.")
+		return defaultWatchInterval
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,58 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	setEnv(t, map[string]string{"LOG_LEVEL": "info", "SERVER_PORT": "5000"})
+	cfg, err := Load()
+	require.NoError(t, err)
+	reloader := NewReloader(cfg)
+
+	var calls int
+	reloader.OnReload(func(previous, current *Config) {
+		calls++
+		assert.Equal(t, "info", previous.Log.Level)
+	})
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		restart []string
+		wantErr bool
+		level   string
+		port    int
+		jwt     string
+	}{
+		{"reloadable settings apply", map[string]string{"LOG_LEVEL": "debug", "JWT_SECRET_KEY": "rotated"}, nil, false, "debug", 5000, "rotated"},
+		{"other settings need a restart", map[string]string{"SERVER_PORT": "6000"}, []string{"SERVER_PORT"}, false, "info", 5000, "secret"},
+		{"invalid configuration is rejected", map[string]string{"LOG_LEVEL": "loud"}, nil, true, "info", 5000, "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloader.current.Store(cfg)
+			calls = 0
+			setEnv(t, tt.env)
+
+			restart, err := reloader.Reload()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Same(t, cfg, reloader.Config(), "the effective configuration stays active")
+				assert.Zero(t, calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.restart, restart)
+			assert.Equal(t, tt.level, reloader.Config().Log.Level)
+			assert.Equal(t, tt.port, reloader.Config().Server.Port)
+			assert.Equal(t, tt.jwt, reloader.Config().JWT.SecretKey)
+			assert.Equal(t, 1, calls)
+			assert.Equal(t, "info", cfg.Log.Level, "the previous configuration is not modified")
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package settings
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/fiber-go-template/config/secrets"
+)
+
+// secretsTimeout bounds the reading of the secret providers.
+const secretsTimeout = 10 * time.Second
+
+// secretProvider is a configured provider and the env name of its location.
+type secretProvider struct {
+	env      string
+	provider secrets.Provider
+}
+
+// applySecrets sets the settings tagged secret from the encrypted secrets file, then Vault,
+// by env name. The environment still wins: a setting found in the environment or its
+// _FILE file is not replaced.
+func applySecrets(cfg *Config, problems *Error) {
+	providers := secretProviders(cfg.Secrets, problems)
+	if len(providers) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+	defer cancel()
+
+	values := map[string]string{}
+	for _, provider := range providers {
+		loaded, err := secrets.Lookup(ctx, provider.provider)
+		if err != nil {
+			problems.add(provider.env, "failed to read the secrets, %v", err)
+			return
+		}
+		for name, value := range loaded {
+			values[name] = value
+		}
+	}
+
+	eachSetting(reflect.ValueOf(cfg).Elem(), func(field reflect.StructField, setting reflect.Value) {
+		if field.Tag.Get("secret") != "true" {
+			return
+		}
+
+		name := field.Tag.Get("env")
+		value, found := values[name]
+		if !found {
+			return
+		}
+		if raw, err := lookupEnv(field); err != nil || raw != "" {
+			return
+		}
+
+		if err := set(setting, value); err != nil {
+			problems.add(name, "must be a valid %s", setting.Kind())
+		}
+	})
+}
+
+func secretProviders(cfg Secrets, problems *Error) (providers []secretProvider) {
+	if cfg.File != "" && cfg.MasterKey != "" {
+		key, err := secrets.ParseKey(cfg.MasterKey)
+		if err != nil {
+			problems.add("SECRETS_MASTER_KEY", "%v", err)
+		} else {
+			providers = append(providers, secretProvider{"SECRETS_FILE", secrets.NewEncryptedFile(cfg.File, key)})
+		}
+	}
+
+	if cfg.VaultAddress != "" && cfg.VaultToken != "" && cfg.VaultPath != "" {
+		providers = append(providers, secretProvider{"VAULT_ADDR", secrets.NewVault(cfg.VaultAddress, cfg.VaultToken, cfg.VaultMount, cfg.VaultPath)})
+	}
+
+	return providers
+}
@@ -0,0 +1,211 @@
+package settings
+
+import "time"
+
+// Config is the typed configuration of the application, see Load.
+// Each setting has an env name, a YAML key and a default; defaults tagged prod
+// apply when STAGE_STATUS is prod. Settings tagged reload are applied by Reloader
+// without a restart, settings tagged secret are redacted.
+type Config struct {
+	Stage         string `yaml:"stage" env:"STAGE_STATUS" default:"dev" validate:"oneof=dev prod"`
+	DefaultLocale string `yaml:"defaultLocale" env:"DEFAULT_LOCALE" default:"en" validate:"oneof=en id"`
+
+	Server      Server      `yaml:"server"`
+	Log         Log         `yaml:"log"`
+	Tracing     Tracing     `yaml:"tracing"`
+	ErrorReport ErrorReport `yaml:"errorReport"`
+	JWT         JWT         `yaml:"jwt"`
+	AuthCookie  AuthCookie  `yaml:"authCookie"`
+	Database    Database    `yaml:"database"`
+	Redis       Redis       `yaml:"redis"`
+	CORS        CORS        `yaml:"cors"`
+	Security    Security    `yaml:"security"`
+	RateLimit   RateLimit   `yaml:"rateLimit"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	Cache       Cache       `yaml:"cache"`
+	Secrets     Secrets     `yaml:"secrets"`
+}
+
+// Server is the HTTP server configuration.
+type Server struct {
+	Host                      string `yaml:"host" env:"SERVER_HOST" default:"0.0.0.0"`
+	Port                      int    `yaml:"port" env:"SERVER_PORT" default:"5000" validate:"min=1,max=65535"`
+	ReadTimeoutSeconds        int    `yaml:"readTimeoutSeconds" env:"SERVER_READ_TIMEOUT" default:"60" validate:"min=0"`
+	ShutdownDrainSeconds      int    `yaml:"shutdownDrainSeconds" env:"SERVER_SHUTDOWN_DRAIN_SECONDS" default:"5" validate:"min=0"`
+	HealthCheckTimeoutSeconds int    `yaml:"healthCheckTimeoutSeconds" env:"HEALTH_CHECK_TIMEOUT_SECONDS" default:"2" validate:"min=1"`
+	// MetricsPort serves /metrics apart from the API, 0 serves it on Port.
+	MetricsPort int `yaml:"metricsPort" env:"METRICS_PORT" default:"0" validate:"min=0,max=65535"`
+}
+
+// Log is the logger configuration.
+type Log struct {
+	Level          string `yaml:"level" env:"LOG_LEVEL" reload:"true" default:"info" validate:"oneof=trace debug info warn error fatal panic disabled"`
+	Format         string `yaml:"format" env:"LOG_FORMAT" default:"console" validate:"oneof=console json"`
+	File           string `yaml:"file" env:"LOG_FILE"`
+	FileMaxSizeMB  int    `yaml:"fileMaxSizeMB" env:"LOG_FILE_MAX_SIZE_MB" default:"100" validate:"min=1"`
+	FileMaxAgeDays int    `yaml:"fileMaxAgeDays" env:"LOG_FILE_MAX_AGE_DAYS" default:"28" validate:"min=0"`
+	FileMaxBackups int    `yaml:"fileMaxBackups" env:"LOG_FILE_MAX_BACKUPS" default:"5" validate:"min=0"`
+	FileCompress   bool   `yaml:"fileCompress" env:"LOG_FILE_COMPRESS" default:"true"`
+}
+
+// Tracing is the OpenTelemetry configuration, the otlp exporter also reads OTEL_EXPORTER_OTLP_*.
+type Tracing struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none" validate:"oneof=none otlp stdout file"`
+	ServiceName string  `yaml:"serviceName" env:"TRACING_SERVICE_NAME" default:"fiber-go-template" validate:"required"`
+	SampleRatio float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO" default:"1" validate:"gte=0,lte=1"`
+	File        string  `yaml:"file" env:"TRACING_FILE" default:"traces.json" validate:"required_if=Exporter file"`
+}
+
+// ErrorReport is the configuration of the panic and 5xx error sinks.
+type ErrorReport struct {
+	SentryDSN     string `yaml:"sentryDsn" env:"ERROR_REPORT_SENTRY_DSN" secret:"true" validate:"omitempty,url"`
+	File          string `yaml:"file" env:"ERROR_REPORT_FILE"`
+	RatePerMinute int    `yaml:"ratePerMinute" env:"ERROR_REPORT_RATE_PER_MINUTE" default:"10" validate:"min=1"`
+}
+
+// JWT is the configuration of the access and refresh tokens. Reloaded keys verify
+// only the tokens they signed, the tokens issued before the rotation are rejected.
+type JWT struct {
+	SecretKey          string `yaml:"secretKey" env:"JWT_SECRET_KEY" reload:"true" secret:"true" validate:"required"`
+	ExpireMinutes      int    `yaml:"expireMinutes" env:"JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT" default:"15" validate:"min=1"`
+	RefreshKey         string `yaml:"refreshKey" env:"JWT_REFRESH_KEY" reload:"true" secret:"true" validate:"required"`
+	RefreshExpireHours int    `yaml:"refreshExpireHours" env:"JWT_REFRESH_KEY_EXPIRE_HOURS_COUNT" default:"720" validate:"min=1"`
+}
+
+// Expire returns the lifetime of the access tokens.
+func (j JWT) Expire() time.Duration {
+	return time.Duration(j.ExpireMinutes) * time.Minute
+}
+
+// RefreshExpire returns the lifetime of the refresh tokens.
+func (j JWT) RefreshExpire() time.Duration {
+	return time.Duration(j.RefreshExpireHours) * time.Hour
+}
+
+// AuthCookie is the configuration of the cookie auth mode.
+type AuthCookie struct {
+	Enabled  bool   `yaml:"enabled" env:"AUTH_COOKIE_ENABLED" default:"false"`
+	Secure   bool   `yaml:"secure" env:"AUTH_COOKIE_SECURE" default:"true"`
+	SameSite string `yaml:"sameSite" env:"AUTH_COOKIE_SAMESITE" default:"Lax" validate:"oneof=Lax Strict None lax strict none"`
+	Domain   string `yaml:"domain" env:"AUTH_COOKIE_DOMAIN"`
+}
+
+// Database is the SQL database configuration.
+type Database struct {
+	Type string `yaml:"type" env:"DB_TYPE" default:"pgx" validate:"oneof=pgx mysql sqlite"`
+	Host string `yaml:"host" env:"DB_HOST" validate:"required_unless=Type sqlite"`
+	Port int    `yaml:"port" env:"DB_PORT" default:"5432" validate:"min=1,max=65535"`
+	User string `yaml:"user" env:"DB_USER" validate:"required_unless=Type sqlite"`
+	// Password is reloaded, the database connections are opened again when it rotates.
+	Password string `yaml:"password" env:"DB_PASSWORD" reload:"true" secret:"true"`
+	// Name is the database file for SQLite, :memory: keeps the database in memory.
+	Name    string `yaml:"name" env:"DB_NAME" validate:"required"`
+	SSLMode string `yaml:"sslMode" env:"DB_SSL_MODE" default:"disable"`
+
+	MaxConnections     int `yaml:"maxConnections" env:"DB_MAX_CONNECTIONS" default:"100" validate:"min=0"`
+	MaxIdleConnections int `yaml:"maxIdleConnections" env:"DB_MAX_IDLE_CONNECTIONS" default:"10" validate:"min=0"`
+	// MaxLifetimeConnections is in seconds, 0 reuses the connections forever.
+	MaxLifetimeConnections int `yaml:"maxLifetimeConnections" env:"DB_MAX_LIFETIME_CONNECTIONS" default:"2" validate:"min=0"`
+
+	// AutoMigrate applies the embedded migrations on startup.
+	AutoMigrate bool `yaml:"autoMigrate" env:"DB_AUTO_MIGRATE" default:"false"`
+	// MigrateLockTimeoutSeconds bounds the wait for the migration lock held by another replica.
+	MigrateLockTimeoutSeconds int `yaml:"migrateLockTimeoutSeconds" env:"DB_MIGRATE_LOCK_TIMEOUT_SECONDS" default:"60" validate:"min=1"`
+}
+
+// InMemory reports whether the database is an in-memory SQLite database.
+func (d Database) InMemory() bool {
+	return d.Type == "sqlite" && d.Name == ":memory:"
+}
+
+// Redis is the Redis server configuration, Redis is disabled when Host is empty.
+type Redis struct {
+	Host     string `yaml:"host" env:"REDIS_HOST"`
+	Port     int    `yaml:"port" env:"REDIS_PORT" default:"6379" validate:"min=1,max=65535"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DBNumber int    `yaml:"dbNumber" env:"REDIS_DB_NUMBER" default:"0" validate:"min=0"`
+}
+
+// Enabled reports whether a Redis server is configured.
+func (r Redis) Enabled() bool {
+	return r.Host != ""
+}
+
+// CORS is the CORS configuration.
+type CORS struct {
+	Enable           bool   `yaml:"enable" env:"CORS_ENABLE" reload:"true" default:"true"`
+	AllowCredentials bool   `yaml:"allowCredentials" env:"CORS_ALLOW_CREDENTIALS" reload:"true" default:"false"`
+	AllowedHeaders   string `yaml:"allowedHeaders" env:"CORS_ALLOWED_HEADERS" reload:"true"`
+	AllowedMethods   string `yaml:"allowedMethods" env:"CORS_ALLOWED_METHODS" reload:"true"`
+	AllowedOrigins   string `yaml:"allowedOrigins" env:"CORS_ALLOWED_ORIGINS" reload:"true"`
+	MaxAgeSeconds    int    `yaml:"maxAgeSeconds" env:"CORS_MAX_AGE_SECONDS" reload:"true" default:"0" validate:"min=0"`
+}
+
+// Security is the configuration of the security headers.
+type Security struct {
+	HeadersEnabled bool `yaml:"headersEnabled" env:"SECURITY_HEADERS_ENABLED" reload:"true" default:"true"`
+	// HSTSMaxAgeSeconds is only sent over HTTPS.
+	HSTSMaxAgeSeconds     int    `yaml:"hstsMaxAgeSeconds" env:"SECURITY_HSTS_MAX_AGE_SECONDS" reload:"true" default:"0" prod:"31536000" validate:"min=0"`
+	ContentSecurityPolicy string `yaml:"contentSecurityPolicy" env:"SECURITY_CONTENT_SECURITY_POLICY" reload:"true" default:"default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"`
+	FrameOptions          string `yaml:"frameOptions" env:"SECURITY_FRAME_OPTIONS" reload:"true" default:"DENY" validate:"oneof=DENY SAMEORIGIN"`
+}
+
+// RateLimit is the rate limit configuration, Policies is a JSON list of ratelimit.Policy.
+type RateLimit struct {
+	Enabled  bool   `yaml:"enabled" env:"RATE_LIMIT_ENABLED" reload:"true" default:"false"`
+	Backend  string `yaml:"backend" env:"RATE_LIMIT_BACKEND" default:"memory" validate:"oneof=memory redis"`
+	Policies string `yaml:"policies" env:"RATE_LIMIT_POLICIES" reload:"true"`
+	// APIKeys is the comma-separated list of accepted X-API-Key values, api_key
+	// policies count other requests by IP.
+	APIKeys string `yaml:"apiKeys" env:"RATE_LIMIT_API_KEYS" reload:"true" secret:"true"`
+}
+
+// Idempotency is the configuration of the Idempotency-Key middleware.
+type Idempotency struct {
+	Backend  string `yaml:"backend" env:"IDEMPOTENCY_BACKEND" default:"database" validate:"oneof=database redis"`
+	TTLHours int    `yaml:"ttlHours" env:"IDEMPOTENCY_TTL_HOURS" default:"24" validate:"min=1"`
+}
+
+// TTL returns how long the idempotency keys are kept.
+func (i Idempotency) TTL() time.Duration {
+	return time.Duration(i.TTLHours) * time.Hour
+}
+
+// Secrets is the configuration of the secret providers filling the settings tagged secret,
+// see Load. The settings of the providers are only read from the environment and YAML.
+type Secrets struct {
+	// File is a JSON object of secrets encrypted with MasterKey, see cmd/secrets.
+	File      string `yaml:"file" env:"SECRETS_FILE"`
+	MasterKey string `yaml:"masterKey" env:"SECRETS_MASTER_KEY" secret:"true" validate:"required_with=File"`
+
+	VaultAddress string `yaml:"vaultAddress" env:"VAULT_ADDR" validate:"omitempty,url"`
+	VaultToken   string `yaml:"vaultToken" env:"VAULT_TOKEN" secret:"true" validate:"required_with=VaultAddress"`
+	VaultMount   string `yaml:"vaultMount" env:"VAULT_KV_MOUNT" default:"secret"`
+	VaultPath    string `yaml:"vaultPath" env:"VAULT_SECRET_PATH" validate:"required_with=VaultAddress"`
+
+	// RefreshSeconds is how often the providers are read again, 0 only reads them on reload.
+	RefreshSeconds int `yaml:"refreshSeconds" env:"SECRETS_REFRESH_SECONDS" default:"300" validate:"min=0"`
+}
+
+// Enabled reports whether a secret provider is configured.
+func (s Secrets) Enabled() bool {
+	return s.File != "" || s.VaultAddress != ""
+}
+
+// Cache is the configuration of the entity cache.
+type Cache struct {
+	Backend         string `yaml:"backend" env:"CACHE_BACKEND" default:"memory" validate:"oneof=none memory redis tiered"`
+	TTLSeconds      int    `yaml:"ttlSeconds" env:"CACHE_TTL_SECONDS" default:"300" validate:"min=1"`
+	LRUSize         int    `yaml:"lruSize" env:"CACHE_LRU_SIZE" default:"10000" validate:"min=1"`
+	LocalTTLSeconds int    `yaml:"localTtlSeconds" env:"CACHE_LOCAL_TTL_SECONDS" default:"30" validate:"min=1"`
+}
+
+// TTL returns how long values are cached.
+func (c Cache) TTL() time.Duration {
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// LocalTTL returns how long the tiered LRU keeps a value.
+func (c Cache) LocalTTL() time.Duration {
+	return time.Duration(c.LocalTTLSeconds) * time.Second
+}
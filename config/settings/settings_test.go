@@ -0,0 +1,144 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setEnv sets the minimal valid configuration, then env.
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	base := map[string]string{
+		"CONFIG_FILE":     "",
+		"STAGE_STATUS":    "",
+		"DB_TYPE":         "sqlite",
+		"DB_NAME":         ":memory:",
+		"JWT_SECRET_KEY":  "secret",
+		"JWT_REFRESH_KEY": "refresh",
+	}
+	for name, value := range env {
+		base[name] = value
+	}
+	for name, value := range base {
+		t.Setenv(name, value)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const yamlFile = `
+server:
+  port: 6000
+log:
+  level: debug
+profiles:
+  prod:
+    server:
+      port: 7000
+`
+
+func TestLoad(t *testing.T) {
+	config := writeFile(t, "config.yaml", yamlFile)
+	secret := writeFile(t, "jwt_secret", "from-file\n")
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(t *testing.T, cfg *Config)
+	}{
+		{"defaults", nil, func(t *testing.T, cfg *Config) {
+			assert.Equal(t, "dev", cfg.Stage)
+			assert.Equal(t, 5000, cfg.Server.Port)
+			assert.Equal(t, "info", cfg.Log.Level)
+			assert.Equal(t, 0, cfg.Security.HSTSMaxAgeSeconds)
+		}},
+		{"prod defaults", map[string]string{"STAGE_STATUS": "prod"}, func(t *testing.T, cfg *Config) {
+			assert.Equal(t, 31536000, cfg.Security.HSTSMaxAgeSeconds)
+		}},
+		{"yaml file", map[string]string{"CONFIG_FILE": config}, func(t *testing.T, cfg *Config) {
+			assert.Equal(t, 6000, cfg.Server.Port)
+			assert.Equal(t, "debug", cfg.Log.Level)
+		}},
+		{"yaml profile of the stage", map[string]string{"CONFIG_FILE": config, "STAGE_STATUS": "prod"}, func(t *testing.T, cfg *Config) {
+			assert.Equal(t, 7000, cfg.Server.Port)
+			assert.Equal(t, "debug", cfg.Log.Level)
+		}},
+		{"environment overrides yaml", map[string]string{"CONFIG_FILE": config, "SERVER_PORT": "8000"}, func(t *testing.T, cfg *Config) {
+			assert.Equal(t, 8000, cfg.Server.Port)
+		}},
+		{"secret file", map[string]string{"JWT_SECRET_KEY": "", "JWT_SECRET_KEY_FILE": secret}, func(t *testing.T, cfg *Config) {
+			assert.Equal(t, "from-file", cfg.JWT.SecretKey)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			cfg, err := Load()
+			require.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		fields []string
+	}{
+		{"every problem at once", map[string]string{"SERVER_PORT": "http", "LOG_LEVEL": "loud", "JWT_SECRET_KEY": ""},
+			[]string{"SERVER_PORT", "LOG_LEVEL", "JWT_SECRET_KEY"}},
+		{"server database needs a host", map[string]string{"DB_TYPE": "pgx"}, []string{"DB_HOST", "DB_USER"}},
+		{"redis backend needs redis", map[string]string{"CACHE_BACKEND": "redis"}, []string{"REDIS_HOST"}},
+		{"invalid rate limit policies", map[string]string{"RATE_LIMIT_ENABLED": "true", "RATE_LIMIT_POLICIES": "[{}]"}, []string{"RATE_LIMIT_POLICIES"}},
+		{"missing configuration file", map[string]string{"CONFIG_FILE": filepath.Join(t.TempDir(), "missing.yaml")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			_, err := Load()
+			require.Error(t, err)
+			if tt.fields == nil {
+				return
+			}
+
+			var problems *Error
+			require.ErrorAs(t, err, &problems)
+			fields := problems.Fields()
+			for _, name := range tt.fields {
+				assert.Contains(t, fields, name)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{
+		JWT:      JWT{SecretKey: "secret", ExpireMinutes: 15},
+		Database: Database{Type: "pgx", Password: ""},
+		Redis:    Redis{Password: "redis-password"},
+	}
+
+	redacted := cfg.Redacted()
+	jwt := redacted["jwt"].(map[string]interface{})
+	assert.Equal(t, "[REDACTED]", jwt["secretKey"])
+	assert.Equal(t, 15, jwt["expireMinutes"])
+	assert.Equal(t, "[REDACTED]", redacted["redis"].(map[string]interface{})["password"])
+
+	database := redacted["database"].(map[string]interface{})
+	assert.Equal(t, "", database["password"], "an empty secret shows it is not set")
+	assert.Equal(t, "pgx", database["type"])
+}
@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fiber-go-template/helper/ratelimit"
+	"github.com/go-playground/validator/v10"
+)
+
+// Error reports every invalid setting, by env name.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Fields returns the problems by env name.
+func (e *Error) Fields() map[string]string {
+	fields := map[string]string{}
+	for _, problem := range e.Problems {
+		name, message, _ := strings.Cut(problem, ": ")
+		if previous, ok := fields[name]; ok {
+			message = previous + "; " + message
+		}
+		fields[name] = message
+	}
+	return fields
+}
+
+func (e *Error) add(name, format string, args ...interface{}) {
+	e.Problems = append(e.Problems, name+": "+fmt.Sprintf(format, args...))
+}
+
+// validate checks the validate tags of Config and the settings depending on each other.
+func validate(cfg *Config, problems *Error) {
+	err := validator.New().Struct(cfg)
+
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		for _, fieldError := range fieldErrors {
+			problems.add(envName(fieldError.StructNamespace()), "%s", message(fieldError))
+		}
+	}
+
+	usesRedis := cfg.RateLimit.Backend == "redis" || cfg.Idempotency.Backend == "redis" ||
+		cfg.Cache.Backend == "redis" || cfg.Cache.Backend == "tiered"
+	if usesRedis && !cfg.Redis.Enabled() {
+		problems.add("REDIS_HOST", "is required by the redis rate limit, idempotency or cache backend")
+	}
+
+	if cfg.RateLimit.Enabled {
+		if _, err := ratelimit.ParsePolicies(cfg.RateLimit.Policies); err != nil {
+			problems.add("RATE_LIMIT_POLICIES", "%v", err)
+		}
+	}
+}
+
+// envName returns the env name of a field namespace, e.g. Config.JWT.SecretKey.
+func envName(namespace string) string {
+	value := reflect.TypeOf(Config{})
+	parts := strings.Split(namespace, ".")
+	for i, part := range parts[1:] {
+		field, ok := value.FieldByName(part)
+		if !ok {
+			break
+		}
+		if i == len(parts)-2 {
+			if name := field.Tag.Get("env"); name != "" {
+				return name
+			}
+		}
+		value = field.Type
+	}
+	return namespace
+}
+
+func message(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required", "required_if", "required_unless", "required_with":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of %s, got %q", strings.ReplaceAll(fieldError.Param(), " ", ", "), fmt.Sprint(fieldError.Value()))
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s, got %v", fieldError.Param(), fieldError.Value())
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s, got %v", fieldError.Param(), fieldError.Value())
+	case "url":
+		return "must be a URL"
+	}
+	return fmt.Sprintf("failed the %s rule", fieldError.Tag())
+}
@@ -0,0 +1,22 @@
+package tracing
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sqlLiteral matches placeholders, quoted strings and numbers of a SQL query.
+var sqlLiteral = regexp.MustCompile(`\$\d+|'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+
+// SanitizeSQL replaces the literal values of a query with ?, keeping its placeholders,
+// so statements recorded in spans never carry user data.
+func SanitizeSQL(query string) string {
+	query = sqlLiteral.ReplaceAllStringFunc(query, func(match string) string {
+		if strings.HasPrefix(match, "$") {
+			return match
+		}
+		return "?"
+	})
+
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Cookies of the cookie auth mode.
+const (
+	// AccessTokenCookie holds the access token, read by JWTProtected.
+	AccessTokenCookie = "access_token"
+	// RefreshTokenCookie holds the refresh token, only sent to the renew endpoint.
+	RefreshTokenCookie = "refresh_token"
+	// CSRFCookie holds the CSRF token, readable by JavaScript to be sent back in HeaderCSRFToken.
+	CSRFCookie = "csrf_token"
+
+	// HeaderCSRFToken is the header carrying the CSRF token of unsafe requests.
+	HeaderCSRFToken = "X-CSRF-Token"
+
+	refreshTokenPath = "/api/v1/token/renew"
+)
+
+// SetAuthCookies func for set the access, refresh and CSRF token cookies.
+func SetAuthCookies(c *fiber.Ctx, cfg settings.AuthCookie, jwt settings.JWT, tokens *Tokens) error {
+	csrfToken, err := generateCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	accessExpires := time.Now().Add(jwt.Expire())
+	refreshExpires := time.Now().Add(jwt.RefreshExpire())
+
+	c.Cookie(authCookie(cfg, AccessTokenCookie, tokens.Access, "/", accessExpires, true))
+	c.Cookie(authCookie(cfg, RefreshTokenCookie, tokens.Refresh, refreshTokenPath, refreshExpires, true))
+	c.Cookie(authCookie(cfg, CSRFCookie, csrfToken, "/", refreshExpires, false))
+	return nil
+}
+
+// ClearAuthCookies func for expire the cookies set by SetAuthCookies.
+func ClearAuthCookies(c *fiber.Ctx, cfg settings.AuthCookie) {
+	expired := time.Unix(0, 0)
+	c.Cookie(authCookie(cfg, AccessTokenCookie, "", "/", expired, true))
+	c.Cookie(authCookie(cfg, RefreshTokenCookie, "", refreshTokenPath, expired, true))
+	c.Cookie(authCookie(cfg, CSRFCookie, "", "/", expired, false))
+}
+
+// authCookie builds a cookie with the configured Secure, SameSite (Strict, Lax or None) and Domain.
+func authCookie(cfg settings.AuthCookie, name, value, path string, expires time.Time, httpOnly bool) *fiber.Cookie {
+	secure := cfg.Secure
+
+	sameSite := fiber.CookieSameSiteLaxMode
+	switch strings.ToLower(cfg.SameSite) {
+	case fiber.CookieSameSiteStrictMode:
+		sameSite = fiber.CookieSameSiteStrictMode
+	case fiber.CookieSameSiteNoneMode:
+		// Browsers drop SameSite=None cookies without Secure.
+		sameSite, secure = fiber.CookieSameSiteNoneMode, true
+	}
+
+	return &fiber.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   cfg.Domain,
+		Expires:  expires,
+		Secure:   secure,
+		HTTPOnly: httpOnly,
+		SameSite: sameSite,
+	}
+}
+
+func generateCSRFToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -2,11 +2,12 @@ package utils
 
 import (
 	"fmt"
-	"os"
+
+	"github.com/fiber-go-template/config/settings"
 )
 
 // ConnectionURLBuilder func for building URL connection.
-func ConnectionURLBuilder(n string) (string, error) {
+func ConnectionURLBuilder(n string, cfg *settings.Config) (string, error) {
 	// Define URL to connection.
 	var url string
 
@@ -15,37 +16,54 @@ func ConnectionURLBuilder(n string) (string, error) {
 	case "postgres":
 		// URL for PostgreSQL connection.
 		url = fmt.Sprintf(
-			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-			os.Getenv("DB_HOST"),
-			os.Getenv("DB_PORT"),
-			os.Getenv("DB_USER"),
-			os.Getenv("DB_PASSWORD"),
-			os.Getenv("DB_NAME"),
-			os.Getenv("DB_SSL_MODE"),
+			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Database.Host,
+			cfg.Database.Port,
+			cfg.Database.User,
+			cfg.Database.Password,
+			cfg.Database.Name,
+			cfg.Database.SSLMode,
 		)
 	case "mysql":
-		// URL for Mysql connection.
+		// URL for Mysql connection, parseTime scans DATETIME columns into time.Time.
+		url = fmt.Sprintf(
+			"%s:%s@tcp(%s:%d)/%s?parseTime=true",
+			cfg.Database.User,
+			cfg.Database.Password,
+			cfg.Database.Host,
+			cfg.Database.Port,
+			cfg.Database.Name,
+		)
+	case "sqlite":
+		// URL for SQLite connection, DB_NAME is the database file or :memory:.
 		url = fmt.Sprintf(
-			"%s:%s@tcp(%s:%s)/%s",
-			os.Getenv("DB_USER"),
-			os.Getenv("DB_PASSWORD"),
-			os.Getenv("DB_HOST"),
-			os.Getenv("DB_PORT"),
-			os.Getenv("DB_NAME"),
+			"file:%s?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+			cfg.Database.Name,
 		)
+		if !cfg.Database.InMemory() {
+			// Readers don't wait for the writer.
+			url += "&_pragma=journal_mode(WAL)"
+		}
 	case "redis":
 		// URL for Redis connection.
 		url = fmt.Sprintf(
-			"%s:%s",
-			os.Getenv("REDIS_HOST"),
-			os.Getenv("REDIS_PORT"),
+			"%s:%d",
+			cfg.Redis.Host,
+			cfg.Redis.Port,
 		)
 	case "fiber":
 		// URL for Fiber connection.
 		url = fmt.Sprintf(
-			"%s:%s",
-			os.Getenv("SERVER_HOST"),
-			os.Getenv("SERVER_PORT"),
+			"%s:%d",
+			cfg.Server.Host,
+			cfg.Server.Port,
+		)
+	case "metrics":
+		// URL for metrics server connection.
+		url = fmt.Sprintf(
+			"%s:%d",
+			cfg.Server.Host,
+			cfg.Server.MetricsPort,
 		)
 	default:
 		// Return error message.
@@ -4,11 +4,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/fiber-go-template/config/settings"
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -19,16 +19,16 @@ type Tokens struct {
 }
 
 // GenerateNewTokens func for generate a new Access & Refresh tokens.
-func GenerateNewTokens(id string, credentials []string) (*Tokens, error) {
+func GenerateNewTokens(cfg settings.JWT, id string, credentials []string) (*Tokens, error) {
 	// Generate JWT Access token.
-	accessToken, err := generateNewAccessToken(id, credentials)
+	accessToken, err := generateNewAccessToken(cfg, id, credentials)
 	if err != nil {
 		// Return token generation error.
 		return nil, err
 	}
 
 	// Generate JWT Refresh token.
-	refreshToken, err := generateNewRefreshToken()
+	refreshToken, err := generateNewRefreshToken(cfg)
 	if err != nil {
 		// Return token generation error.
 		return nil, err
@@ -40,20 +40,14 @@ func GenerateNewTokens(id string, credentials []string) (*Tokens, error) {
 	}, nil
 }
 
-func generateNewAccessToken(id string, credentials []string) (string, error) {
-	// Set secret key from .env file.
-	secret := os.Getenv("JWT_SECRET_KEY")
-
-	// Set expires minutes count for secret key from .env file.
-	minutesCount, _ := strconv.Atoi(os.Getenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT"))
-
+func generateNewAccessToken(cfg settings.JWT, id string, credentials []string) (string, error) {
 	// Create a new claims.
 	claims := jwt.MapClaims{}
 
 	// Set public claims:
 	claims["userId"] = id
 	claims["iat"] = time.Now().Unix()
-	claims["exp"] = time.Now().Add(time.Minute * time.Duration(minutesCount)).Unix()
+	claims["exp"] = time.Now().Add(cfg.Expire()).Unix()
 	// claims["book:create"] = false
 	// claims["book:update"] = false
 	// claims["book:delete"] = false
@@ -67,7 +61,7 @@ func generateNewAccessToken(id string, credentials []string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// Generate token.
-	t, err := token.SignedString([]byte(secret))
+	t, err := token.SignedString([]byte(cfg.SecretKey))
 	if err != nil {
 		// Return error, it JWT token generation failed.
 		return "", err
@@ -76,12 +70,12 @@ func generateNewAccessToken(id string, credentials []string) (string, error) {
 	return t, nil
 }
 
-func generateNewRefreshToken() (string, error) {
+func generateNewRefreshToken(cfg settings.JWT) (string, error) {
 	// Create a new SHA256 hash.
 	hash := sha256.New()
 
 	// Create a new now date and time string with salt.
-	refresh := os.Getenv("JWT_REFRESH_KEY") + time.Now().String()
+	refresh := cfg.RefreshKey + time.Now().String()
 
 	// See: https://pkg.go.dev/io#Writer.Write
 	_, err := hash.Write([]byte(refresh))
@@ -90,11 +84,8 @@ func generateNewRefreshToken() (string, error) {
 		return "", err
 	}
 
-	// Set expires hours count for refresh key from .env file.
-	hoursCount, _ := strconv.Atoi(os.Getenv("JWT_REFRESH_KEY_EXPIRE_HOURS_COUNT"))
-
 	// Set expiration time.
-	expireTime := fmt.Sprint(time.Now().Add(time.Hour * time.Duration(hoursCount)).Unix())
+	expireTime := fmt.Sprint(time.Now().Add(cfg.RefreshExpire()).Unix())
 
 	// Create a new refresh token (sha256 string with salt + expire time).
 	t := hex.EncodeToString(hash.Sum(nil)) + "." + expireTime
@@ -104,5 +95,10 @@ func generateNewRefreshToken() (string, error) {
 
 // ParseRefreshToken func for parse second argument from refresh token.
 func ParseRefreshToken(refreshToken string) (int64, error) {
-	return strconv.ParseInt(strings.Split(refreshToken, ".")[1], 0, 64)
+	parts := strings.Split(refreshToken, ".")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("malformed refresh token")
+	}
+
+	return strconv.ParseInt(parts[1], 0, 64)
 }
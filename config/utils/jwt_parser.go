@@ -1,9 +1,10 @@
 package utils
 
 import (
-	"os"
+	"errors"
 	"strings"
 
+	"github.com/fiber-go-template/config/settings"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofrs/uuid"
 	"github.com/golang-jwt/jwt/v5"
@@ -16,43 +17,62 @@ type TokenMetadata struct {
 	Expires     int64
 }
 
-// ExtractTokenMetadata func to extract metadata from JWT.
+// ExtractTokenMetadata func to extract metadata from the JWT validated by JWTProtected.
 func ExtractTokenMetadata(c *fiber.Ctx) (*TokenMetadata, error) {
-	token, err := verifyToken(c)
+	token, ok := c.Locals("jwt").(*jwt.Token)
+	if !ok {
+		return nil, errors.New("missing or malformed JWT")
+	}
+
+	return tokenMetadata(token)
+}
+
+// ParseTokenMetadata func to verify the JWT of the Authorization header, or of the
+// access token cookie in cookie auth mode, and extract its metadata.
+// It is used before JWTProtected runs, e.g. by the rate limiter.
+func ParseTokenMetadata(c *fiber.Ctx, cfg settings.JWT, cookie settings.AuthCookie) (*TokenMetadata, error) {
+	token, err := jwt.Parse(extractToken(c, cookie), func(token *jwt.Token) (interface{}, error) {
+		return []byte(cfg.SecretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		return nil, err
 	}
 
+	return tokenMetadata(token)
+}
+
+func tokenMetadata(token *jwt.Token) (*TokenMetadata, error) {
 	// Setting and checking token and credentials.
 	claims, ok := token.Claims.(jwt.MapClaims)
-	if ok && token.Valid {
-		// User ID.
-		userID, err := uuid.FromString(claims["userId"].(string))
-		if err != nil {
-			return nil, err
-		}
-
-		// Expires time.
-		expires := int64(claims["exp"].(float64))
-
-		// User credentials.
-		credentials := map[string]bool{
-			// "book:create": claims["book:create"].(bool),
-			// "book:update": claims["book:update"].(bool),
-			// "book:delete": claims["book:delete"].(bool),
-		}
-
-		return &TokenMetadata{
-			UserID:      userID,
-			Credentials: credentials,
-			Expires:     expires,
-		}, nil
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid or expired JWT")
+	}
+
+	// User ID.
+	rawUserID, _ := claims["userId"].(string)
+	userID, err := uuid.FromString(rawUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expires time.
+	expires, _ := claims["exp"].(float64)
+
+	// User credentials.
+	credentials := map[string]bool{
+		// "book:create": claims["book:create"].(bool),
+		// "book:update": claims["book:update"].(bool),
+		// "book:delete": claims["book:delete"].(bool),
 	}
 
-	return nil, err
+	return &TokenMetadata{
+		UserID:      userID,
+		Credentials: credentials,
+		Expires:     int64(expires),
+	}, nil
 }
 
-func extractToken(c *fiber.Ctx) string {
+func extractToken(c *fiber.Ctx, cookie settings.AuthCookie) string {
 	bearToken := c.Get("Authorization")
 
 	// Normally Authorization HTTP header.
@@ -61,20 +81,10 @@ func extractToken(c *fiber.Ctx) string {
 		return onlyToken[1]
 	}
 
-	return ""
-}
-
-func verifyToken(c *fiber.Ctx) (*jwt.Token, error) {
-	tokenString := extractToken(c)
-
-	token, err := jwt.Parse(tokenString, jwtKeyFunc)
-	if err != nil {
-		return nil, err
+	// Then the HttpOnly cookie of the cookie auth mode.
+	if cookie.Enabled {
+		return c.Cookies(AccessTokenCookie)
 	}
 
-	return token, nil
-}
-
-func jwtKeyFunc(token *jwt.Token) (interface{}, error) {
-	return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+	return ""
 }
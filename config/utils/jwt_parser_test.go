@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTokenMetadata(t *testing.T) {
+	cfg := settings.JWT{SecretKey: "secret", ExpireMinutes: 15, RefreshExpireHours: 1}
+	userID, _ := uuid.NewV4()
+
+	sign := func(method jwt.SigningMethod, key interface{}, exp time.Duration) string {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{"userId": userID.String(), "exp": time.Now().Add(exp).Unix()})
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+	generated, err := GenerateNewTokens(cfg, userID.String(), nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"generated token", generated.Access, false},
+		{"HS256", sign(jwt.SigningMethodHS256, []byte("secret"), time.Minute), false},
+		{"other HMAC method", sign(jwt.SigningMethodHS512, []byte("secret"), time.Minute), true},
+		{"unsigned", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, time.Minute), true},
+		{"other key", sign(jwt.SigningMethodHS256, []byte("other"), time.Minute), true},
+		{"expired", sign(jwt.SigningMethodHS256, []byte("secret"), -time.Minute), true},
+		{"missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var metadata *TokenMetadata
+			var parseErr error
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				metadata, parseErr = ParseTokenMetadata(c, cfg, settings.AuthCookie{})
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+			_, err := app.Test(req, -1)
+			require.NoError(t, err)
+
+			if tt.wantErr {
+				assert.Error(t, parseErr)
+				return
+			}
+			require.NoError(t, parseErr)
+			assert.Equal(t, userID, metadata.UserID)
+		})
+	}
+}
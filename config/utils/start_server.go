@@ -4,20 +4,28 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/helper/health"
 	"github.com/gofiber/fiber/v2"
 )
 
 // StartServerWithGracefulShutdown function for starting server with a graceful shutdown.
-func StartServerWithGracefulShutdown(a *fiber.App) {
+func StartServerWithGracefulShutdown(a *fiber.App, cfg *settings.Config) {
 	// Create channel for idle connections.
 	idleConnsClosed := make(chan struct{})
 
 	go func() {
 		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt) // Catch OS signals.
+		signal.Notify(sigint, os.Interrupt, syscall.SIGTERM) // Catch OS signals.
 		<-sigint
 
+		// Report not ready, then give the load balancer time to stop sending traffic.
+		health.SetShuttingDown()
+		time.Sleep(time.Duration(cfg.Server.ShutdownDrainSeconds) * time.Second)
+
 		// Received an interrupt signal, shutdown.
 		if err := a.Shutdown(); err != nil {
 			// Error from closing listeners, or context timeout:
@@ -28,7 +36,7 @@ func StartServerWithGracefulShutdown(a *fiber.App) {
 	}()
 
 	// Build Fiber connection URL.
-	fiberConnURL, _ := ConnectionURLBuilder("fiber")
+	fiberConnURL, _ := ConnectionURLBuilder("fiber", cfg)
 
 	// Run server.
 	if err := a.Listen(fiberConnURL); err != nil {
@@ -39,12 +47,23 @@ func StartServerWithGracefulShutdown(a *fiber.App) {
 }
 
 // StartServer func for starting a simple server.
-func StartServer(a *fiber.App) {
+func StartServer(a *fiber.App, cfg *settings.Config) {
 	// Build Fiber connection URL.
-	fiberConnURL, _ := ConnectionURLBuilder("fiber")
+	fiberConnURL, _ := ConnectionURLBuilder("fiber", cfg)
 
 	// Run server.
 	if err := a.Listen(fiberConnURL); err != nil {
 		log.Printf("Oops... Server is not running! Reason: %v", err)
 	}
 }
+
+// StartMetricsServer func for starting the metrics server on its own port.
+func StartMetricsServer(a *fiber.App, cfg *settings.Config) {
+	// Build metrics connection URL.
+	metricsConnURL, _ := ConnectionURLBuilder("metrics", cfg)
+
+	// Run server.
+	if err := a.Listen(metricsConnURL); err != nil {
+		log.Printf("Oops... Metrics server is not running! Reason: %v", err)
+	}
+}
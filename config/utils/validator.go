@@ -1,10 +1,27 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
 	"github.com/google/uuid"
 )
 
+var (
+	validate     *validator.Validate
+	translator   *ut.UniversalTranslator
+	validateOnce sync.Once
+)
+
 // NewValidator func for create a new validator for model fields.
 func NewValidator() *validator.Validate {
 	// Create a new validator for a Book model.
@@ -22,15 +39,82 @@ func NewValidator() *validator.Validate {
 	return validate
 }
 
-// ValidatorErrors func for show validation errors for each invalid fields.
-func ValidatorErrors(err error) map[string]string {
+// Validator func for get the shared validator, created on first use.
+// Fields are named after their json, query or params tag in validation errors.
+func Validator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = NewValidator()
+		validate.RegisterTagNameFunc(tagName)
+
+		// Register the English and Indonesian validation messages.
+		translator = ut.New(en.New(), en.New(), id.New())
+		enTranslator, _ := translator.GetTranslator("en")
+		_ = enTranslations.RegisterDefaultTranslations(validate, enTranslator)
+		idTranslator, _ := translator.GetTranslator("id")
+		_ = idTranslations.RegisterDefaultTranslations(validate, idTranslator)
+	})
+
+	return validate
+}
+
+// ValidatorErrors func for show validation errors for each invalid fields, in the given locale.
+// Errors other than validator.ValidationErrors give an empty map.
+func ValidatorErrors(err error, locale string) map[string]string {
 	// Define fields map.
 	fields := map[string]string{}
 
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fields
+	}
+
+	Validator()
+	trans, _ := translator.GetTranslator(locale)
+
 	// Make error message for each invalid field.
-	for _, err := range err.(validator.ValidationErrors) {
-		fields[err.Field()] = err.Error()
+	for _, err := range validationErrors {
+		fields[fieldPath(err)] = validationMessage(err, trans)
 	}
 
 	return fields
 }
+
+// tagName returns the name a field is sent with, "" falls back to the Go field name.
+func tagName(field reflect.StructField) string {
+	for _, key := range []string{"json", "query", "params"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// fieldPath returns the namespace of the field without the root struct, e.g. address.city.
+func fieldPath(err validator.FieldError) string {
+	namespace := err.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// validationMessage returns the translated message of the rule, or a generic one
+// for rules without translation.
+func validationMessage(err validator.FieldError, trans ut.Translator) string {
+	if message := err.Translate(trans); message != err.Error() {
+		return message
+	}
+
+	if err.Tag() == "required" {
+		return "is required"
+	}
+	if err.Param() != "" {
+		return fmt.Sprintf("must satisfy %s=%s", err.Tag(), err.Param())
+	}
+	return fmt.Sprintf("must satisfy %s", err.Tag())
+}
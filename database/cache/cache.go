@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/helper/metrics"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// Store keeps encoded values by key.
+type Store interface {
+	// Get returns the value of key, ok is false when the key is missing or expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix deletes every key starting with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// Cache is a read-through cache of a named group of keys, e.g. author.
+// A nil Cache loads every value, so caching can be turned off.
+type Cache struct {
+	Name  string
+	Store Store
+	TTL   time.Duration
+
+	group singleflight.Group
+
+	// mu guards loads, the loads in flight by key, and orders their Set with Invalidate.
+	mu    sync.Mutex
+	loads map[string]*pending
+}
+
+// pending is a load in flight, stale once its key is invalidated.
+type pending struct {
+	stale bool
+}
+
+func New(name string, store Store, ttl time.Duration) *Cache {
+	return &Cache{
+		Name:  name,
+		Store: store,
+		TTL:   ttl,
+	}
+}
+
+// GetOrLoad returns the cached value of key, or loads, caches and returns it.
+// Concurrent misses of the same key share a single load, run with the values of
+// ctx but never cancelled by it. Store errors are logged and the value is loaded,
+// the cache must never fail a request.
+func GetOrLoad[T any](ctx context.Context, c *Cache, key string, load func(ctx context.Context) (T, error)) (res T, err error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	key = c.Name + ":" + key
+	if raw, ok, err := c.Store.Get(ctx, key); err != nil {
+		logger.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("Failed to read the cache.")
+	} else if ok && json.Unmarshal(raw, &res) == nil {
+		metrics.CacheRequests.WithLabelValues(c.Name, metrics.CacheHit).Inc()
+		return res, nil
+	}
+	metrics.CacheRequests.WithLabelValues(c.Name, metrics.CacheMiss).Inc()
+
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		// The load is shared, a caller leaving must not fail the others.
+		ctx := detached{ctx}
+		flight := c.start(key)
+		value, err := load(ctx)
+		if err != nil {
+			c.finish(key, flight)
+			return value, err
+		}
+
+		if raw, err := json.Marshal(value); err == nil {
+			c.set(ctx, key, flight, raw)
+		}
+		c.finish(key, flight)
+		return value, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res, _ = value.(T)
+	return res, nil
+}
+
+// detached keeps the values of its parent, e.g. the logger and the span, without
+// its deadline and cancellation. context.WithoutCancel needs Go 1.21.
+type detached struct {
+	parent context.Context
+}
+
+func (d detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (d detached) Done() <-chan struct{} { return nil }
+
+func (d detached) Err() error { return nil }
+
+func (d detached) Value(key interface{}) interface{} { return d.parent.Value(key) }
+
+// start registers a load of key in flight.
+func (c *Cache) start(key string) *pending {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loads == nil {
+		c.loads = make(map[string]*pending)
+	}
+	flight := &pending{}
+	c.loads[key] = flight
+	return flight
+}
+
+// finish removes the load of key, unless a newer load replaced it.
+func (c *Cache) finish(key string, flight *pending) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loads[key] == flight {
+		delete(c.loads, key)
+	}
+}
+
+// set caches the loaded value of key, unless the key was invalidated during the load:
+// the value may have been read before the write and would be served for the whole TTL.
+func (c *Cache) set(ctx context.Context, key string, flight *pending, raw []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if flight.stale {
+		return
+	}
+	if err := c.Store.Set(ctx, key, raw, c.TTL); err != nil {
+		logger.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("Failed to write the cache.")
+	}
+}
+
+// Invalidate deletes the cached keys starting with each prefix. Loads of these keys
+// in flight are not cached, and the next misses load again instead of sharing them.
+func (c *Cache) Invalidate(ctx context.Context, prefixes ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	for key, flight := range c.loads {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, c.Name+":"+prefix) {
+				flight.stale = true
+				c.group.Forget(key)
+				break
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	for _, prefix := range prefixes {
+		if err := c.Store.DeletePrefix(ctx, c.Name+":"+prefix); err != nil {
+			logger.Ctx(ctx).Warn().Err(err).Str("prefix", prefix).Msg("Failed to invalidate the cache.")
+		}
+	}
+}
+
+// NewStore func for create the cache store of the configured backend:
+//   - none, caching is off and the store is nil
+//   - memory (default), an in-process LRU
+//   - redis, shared by every instance
+//   - tiered, the in-process LRU in front of Redis, invalidated on every instance
+func NewStore(cfg *settings.Config) (Store, error) {
+	backend := strings.ToLower(cfg.Cache.Backend)
+	if backend == "none" {
+		return nil, nil
+	}
+
+	size := cfg.Cache.LRUSize
+	if backend == "" || backend == "memory" {
+		return NewLRU(size), nil
+	}
+
+	client, err := RedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case "redis":
+		return NewRedisStore(client), nil
+	case "tiered":
+		tiered := NewTiered(NewLRU(size), NewRedisStore(client), cfg.Cache.LocalTTL())
+		go func() {
+			if err := tiered.Subscribe(context.Background()); err != nil {
+				log.Error().Err(err).Msg("Cache invalidations are not received.")
+			}
+		}()
+		return tiered, nil
+	}
+
+	return nil, errors.New("unsupported cache backend: " + backend)
+}
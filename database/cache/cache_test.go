@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey struct{}
+
+func TestGetOrLoad(t *testing.T) {
+	c := New("author", NewLRU(10), time.Minute)
+	ctx := context.Background()
+
+	var loads int32
+	load := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&loads, 1)
+		return "Pramoedya", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := GetOrLoad(ctx, c, "id:1", load)
+		require.NoError(t, err)
+		assert.Equal(t, "Pramoedya", value)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+
+	_, err := GetOrLoad(ctx, c, "id:2", func(ctx context.Context) (string, error) { return "", errors.New("not found") })
+	assert.Error(t, err)
+	_, ok, _ := c.Store.Get(ctx, "author:id:2")
+	assert.False(t, ok, "errors are not cached")
+}
+
+func TestGetOrLoadDetachesContext(t *testing.T) {
+	c := New("author", NewLRU(10), time.Minute)
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), contextKey{}, "request"), time.Millisecond)
+	cancel()
+
+	value, err := GetOrLoad(ctx, c, "id:1", func(ctx context.Context) (string, error) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		_, hasDeadline := ctx.Deadline()
+		assert.False(t, hasDeadline)
+		return ctx.Value(contextKey{}).(string), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "request", value)
+}
+
+func TestGetOrLoadNilCache(t *testing.T) {
+	var c *Cache
+	value, err := GetOrLoad(context.Background(), c, "id:1", func(ctx context.Context) (int, error) { return 7, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 7, value)
+	c.Invalidate(context.Background(), "id:1")
+}
+
+func TestInvalidate(t *testing.T) {
+	keys := []string{"all:", "all:filters|name", "id:1:", "id:12:", "id:2:"}
+
+	tests := []struct {
+		name     string
+		prefixes []string
+		kept     []string
+	}{
+		{"lists", []string{"all:"}, []string{"id:1:", "id:12:", "id:2:"}},
+		{"one entity", []string{"id:1:"}, []string{"all:", "all:filters|name", "id:12:", "id:2:"}},
+		{"entity and lists", []string{"id:2:", "all:"}, []string{"id:1:", "id:12:"}},
+		{"nothing matches", []string{"id:3:"}, keys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := New("author", NewLRU(10), time.Minute)
+			other := New("book", c.Store, time.Minute)
+			for _, key := range keys {
+				_, err := GetOrLoad(ctx, c, key, func(ctx context.Context) (string, error) { return key, nil })
+				require.NoError(t, err)
+			}
+			_, err := GetOrLoad(ctx, other, "all:", func(ctx context.Context) (string, error) { return "books", nil })
+			require.NoError(t, err)
+
+			c.Invalidate(ctx, tt.prefixes...)
+
+			var kept []string
+			for _, key := range keys {
+				if _, ok, _ := c.Store.Get(ctx, "author:"+key); ok {
+					kept = append(kept, key)
+				}
+			}
+			assert.Equal(t, tt.kept, kept)
+			_, ok, _ := c.Store.Get(ctx, "book:all:")
+			assert.True(t, ok, "the other caches are kept")
+		})
+	}
+}
+
+// TestInvalidateDuringLoad checks that a load in flight when its key is invalidated
+// does not cache the value read before the write.
+func TestInvalidateDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	c := New("author", NewLRU(10), time.Minute)
+
+	loading, release := make(chan struct{}), make(chan struct{})
+	done := make(chan string)
+	go func() {
+		value, err := GetOrLoad(ctx, c, "id:1:", func(ctx context.Context) (string, error) {
+			close(loading)
+			<-release
+			return "before", nil
+		})
+		assert.NoError(t, err)
+		done <- value
+	}()
+
+	<-loading
+	c.Invalidate(ctx, "id:1:")
+
+	// The next miss does not share the stale load.
+	value, err := GetOrLoad(ctx, c, "id:1:", func(ctx context.Context) (string, error) { return "after", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "after", value)
+
+	close(release)
+	assert.Equal(t, "before", <-done)
+
+	value, err = GetOrLoad(ctx, c, "id:1:", func(ctx context.Context) (string, error) { return "reloaded", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "after", value, "the stale load is not cached")
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	redisClient     *redis.Client
+	redisClientErr  error
+	redisClientOnce sync.Once
+)
+
+// RedisClient func for get the Redis client shared by the application, created on first use.
+func RedisClient(cfg *settings.Config) (*redis.Client, error) {
+	redisClientOnce.Do(func() {
+		redisClient, redisClientErr = RedisConnection(cfg)
+	})
+
+	return redisClient, redisClientErr
+}
+
+// RedisConnection func for connect to Redis server.
+func RedisConnection(cfg *settings.Config) (*redis.Client, error) {
+	// Build Redis connection URL.
+	redisConnURL, err := utils.ConnectionURLBuilder("redis", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set Redis options.
+	options := &redis.Options{
+		Addr:     redisConnURL,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DBNumber,
+	}
+
+	return redis.NewClient(options), nil
+}
+
+// RedisStore is a Store shared by every instance, keys are prefixed with cache:.
+type RedisStore struct {
+	Client *redis.Client
+	Prefix string
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		Client: client,
+		Prefix: "cache:",
+	}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.Client.Get(ctx, s.Prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.Client.Set(ctx, s.Prefix+key, value, ttl).Err()
+}
+
+// DeletePrefix scans the matching keys, it never blocks Redis like KEYS would.
+func (s *RedisStore) DeletePrefix(ctx context.Context, prefix string) error {
+	iter := s.Client.Scan(ctx, 0, s.Prefix+escapePattern(prefix)+"*", 100).Iterator()
+
+	keys := make([]string, 0, 100)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == cap(keys) {
+			if err := s.Client.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		return s.Client.Unlink(ctx, keys...).Err()
+	}
+	return nil
+}
+
+// escapePattern escapes the glob characters of a SCAN pattern.
+func escapePattern(value string) string {
+	var escaped []byte
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '*', '?', '[', ']', '\\':
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, value[i])
+	}
+	return string(escaped)
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// invalidationChannel is the Redis channel broadcasting the deleted prefixes to every instance.
+const invalidationChannel = "cache:invalidate"
+
+// Tiered is a Store reading an in-process LRU before Redis. The LRU keeps values at
+// most localTTL and invalidations are broadcast, so every instance drops them.
+type Tiered struct {
+	Local    *LRU
+	Remote   *RedisStore
+	localTTL time.Duration
+}
+
+func NewTiered(local *LRU, remote *RedisStore, localTTL time.Duration) *Tiered {
+	return &Tiered{
+		Local:    local,
+		Remote:   remote,
+		localTTL: localTTL,
+	}
+}
+
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if value, ok, _ := t.Local.Get(ctx, key); ok {
+		return value, true, nil
+	}
+
+	value, ok, err := t.Remote.Get(ctx, key)
+	if err != nil || !ok {
+		return value, ok, err
+	}
+
+	_ = t.Local.Set(ctx, key, value, t.localTTL)
+	return value, true, nil
+}
+
+func (t *Tiered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	local := ttl
+	if local > t.localTTL {
+		local = t.localTTL
+	}
+	_ = t.Local.Set(ctx, key, value, local)
+
+	return t.Remote.Set(ctx, key, value, ttl)
+}
+
+func (t *Tiered) DeletePrefix(ctx context.Context, prefix string) error {
+	_ = t.Local.DeletePrefix(ctx, prefix)
+	if err := t.Remote.DeletePrefix(ctx, prefix); err != nil {
+		return err
+	}
+
+	return t.Remote.Client.Publish(ctx, invalidationChannel, prefix).Err()
+}
+
+// Subscribe drops the prefixes invalidated by other instances from the LRU until ctx is done.
+func (t *Tiered) Subscribe(ctx context.Context) error {
+	pubsub := t.Remote.Client.Subscribe(ctx, invalidationChannel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			_ = t.Local.DeletePrefix(ctx, message.Payload)
+		}
+	}
+}
@@ -1,72 +1,187 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"sync/atomic"
+	"time"
 
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/database/dialect"
+	"github.com/fiber-go-template/helper/metrics"
 	"github.com/jmoiron/sqlx"
 	"gorm.io/gorm"
 )
 
+// closeDelay is how long the replaced pools of Reconnect keep serving the requests
+// that already got them.
+const closeDelay = 30 * time.Second
+
+// DBConn gives the sqlx and GORM pools, swapped by Reconnect. Copies share the pools.
 type DBConn struct {
-	DB   *sqlx.DB
-	Gorm *gorm.DB
+	pools   *atomic.Pointer[pools]
+	dialect dialect.Dialect
+}
+
+type pools struct {
+	db   *sqlx.DB
+	gorm *gorm.DB
 }
 
 func (d DBConn) Query() (db *sqlx.DB) {
-	db = d.DB
+	db = d.pools.Load().db
 	return
 }
 
 func (d DBConn) Orm() (db *gorm.DB) {
-	db = d.Gorm
+	db = d.pools.Load().gorm
 	return
 }
 
-// OpenDBConnection func for opening database connection.
-func NewDBConnection() (dbConn DBConn, err error) {
+// Dialect returns the SQL dialect of the database.
+func (d DBConn) Dialect() dialect.Dialect {
+	return d.dialect
+}
+
+// Opened reports whether the pools are opened, even when the database is not reachable.
+func (d DBConn) Opened() bool {
+	return d.pools != nil
+}
+
+// Ping func for check that both the sqlx and GORM pools reach the database.
+func (d DBConn) Ping(ctx context.Context) error {
+	return d.pools.Load().ping(ctx)
+}
+
+// Reconnect func for open new pools with cfg, e.g. when the database password rotates.
+// The new pools replace the current ones once they reach the database, the current
+// ones are closed after closeDelay.
+func (d DBConn) Reconnect(cfg *settings.Config) error {
+	next, err := openPools(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := next.ping(ctx); err != nil {
+		next.close()
+		return err
+	}
+
+	previous := d.pools.Swap(next)
+	next.register()
+	time.AfterFunc(closeDelay, previous.close)
+
+	return nil
+}
+
+// NewDBConnection func for opening database connection.
+// When the database is not reachable the connection is still returned with the
+// ping error, the pools reconnect once it is up.
+func NewDBConnection(cfg *settings.Config) (dbConn DBConn, err error) {
+	sqlDialect, err := dialect.New(cfg.Database.Type)
+	if err != nil {
+		return dbConn, err
+	}
+
+	opened, err := openPools(cfg)
+	if err != nil {
+		return dbConn, err
+	}
+
+	dbConn = DBConn{pools: &atomic.Pointer[pools]{}, dialect: sqlDialect}
+	dbConn.pools.Store(opened)
+	opened.register()
+
+	// Try to ping database.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return dbConn, dbConn.Ping(ctx)
+}
+
+func openPools(cfg *settings.Config) (*pools, error) {
 	// Define Database connection variables.
 	var (
 		db   *sqlx.DB
 		gorm *gorm.DB
+		err  error
 	)
 
-	// Get DB_TYPE value from .env file.
-	dbType := os.Getenv("DB_TYPE")
+	// Get the configured DB_TYPE.
+	dbType := cfg.Database.Type
 
 	// Define a new Database connection with right DB type.
 	switch dbType {
 	case "pgx":
-		db, err = PostgreSQLConnection()
+		db, err = PostgreSQLConnection(cfg)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			return nil, err
 		}
 
-		gorm, err = GormPostgreSQLConnection()
+		gorm, err = GormPostgreSQLConnection(cfg)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			return nil, err
 		}
 
 	case "mysql":
-		db, err = MysqlConnection()
+		db, err = MysqlConnection(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		gorm, err = GormMysqlConnection(cfg)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			return nil, err
 		}
 
-		gorm, err = GormMysqlConnection()
+	case "sqlite":
+		db, err = SQLiteConnection(cfg)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			return nil, err
 		}
+
+		gorm, err = GormSQLiteConnection(db)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("database type '%v' is not supported", dbType)
 	}
 
-	dbConn = DBConn{
-		DB:   db,
-		Gorm: gorm,
+	return &pools{db: db, gorm: gorm}, nil
+}
+
+func (p *pools) ping(ctx context.Context) error {
+	if err := p.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("error, not sent ping to database, %w", err)
+	}
+
+	sqlDB, err := p.gorm.DB()
+	if err != nil {
+		return err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("error, not sent ping to database, %w", err)
+	}
+
+	return nil
+}
+
+// register exposes the pool stats of both connections.
+func (p *pools) register() {
+	metrics.RegisterDB("sqlx", p.db.DB)
+	if sqlDB, err := p.gorm.DB(); err == nil {
+		metrics.RegisterDB("gorm", sqlDB)
 	}
+}
 
-	return dbConn, nil
+func (p *pools) close() {
+	_ = p.db.Close()
+	if sqlDB, err := p.gorm.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
 }
@@ -0,0 +1,178 @@
+// Package dialect describes the SQL differences between the supported databases,
+// so repositories write one query for all of them.
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Dialect builds the SQL fragments differing between databases.
+type Dialect interface {
+	// Name returns the DB_TYPE of the database.
+	Name() string
+	// Rebind converts the ? placeholders of query to the placeholders of the database.
+	Rebind(query string) string
+	// ILike returns a case-insensitive LIKE of expression against a ? placeholder.
+	ILike(expression string) string
+	// Concat returns the concatenation of columns, NULL columns are skipped.
+	Concat(columns ...string) string
+	// Bool returns the literal of value.
+	Bool(value bool) string
+	// UUIDType returns the column type storing UUIDs.
+	UUIDType() string
+	// Upsert returns an INSERT of columns into table with ? placeholders,
+	// updating the update columns when a row with the same conflict columns exists.
+	Upsert(table string, columns, conflict, update []string) string
+}
+
+// New returns the dialect of dbType.
+func New(dbType string) (Dialect, error) {
+	switch dbType {
+	case "pgx":
+		return Postgres{}, nil
+	case "mysql":
+		return MySQL{}, nil
+	case "sqlite":
+		return SQLite{}, nil
+	default:
+		return nil, fmt.Errorf("database type '%v' is not supported", dbType)
+	}
+}
+
+// Postgres is the PostgreSQL dialect.
+type Postgres struct{}
+
+func (Postgres) Name() string {
+	return "pgx"
+}
+
+func (Postgres) Rebind(query string) string {
+	return sqlx.Rebind(sqlx.DOLLAR, query)
+}
+
+func (Postgres) ILike(expression string) string {
+	return expression + " ILIKE ?"
+}
+
+func (Postgres) Concat(columns ...string) string {
+	return "concat(" + strings.Join(columns, ", ") + ")"
+}
+
+func (Postgres) Bool(value bool) string {
+	if value {
+		return "true"
+	}
+	return "false"
+}
+
+func (Postgres) UUIDType() string {
+	return "UUID"
+}
+
+func (d Postgres) Upsert(table string, columns, conflict, update []string) string {
+	assignments := make([]string, len(update))
+	for i, column := range update {
+		assignments[i] = column + " = EXCLUDED." + column
+	}
+
+	query := insert(table, columns) + " ON CONFLICT (" + strings.Join(conflict, ", ") + ")"
+	if len(assignments) == 0 {
+		return query + " DO NOTHING"
+	}
+	return query + " DO UPDATE SET " + strings.Join(assignments, ", ")
+}
+
+// MySQL is the MySQL dialect, UUIDs are stored as CHAR(36) text.
+type MySQL struct{}
+
+func (MySQL) Name() string {
+	return "mysql"
+}
+
+func (MySQL) Rebind(query string) string {
+	return query
+}
+
+// ILike lowers both sides, the column collation may be case-sensitive.
+func (MySQL) ILike(expression string) string {
+	return "LOWER(" + expression + ") LIKE LOWER(?)"
+}
+
+// Concat uses CONCAT_WS, CONCAT returns NULL when a column is NULL.
+func (MySQL) Concat(columns ...string) string {
+	return "CONCAT_WS('', " + strings.Join(columns, ", ") + ")"
+}
+
+func (MySQL) Bool(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (MySQL) UUIDType() string {
+	return "CHAR(36)"
+}
+
+// Upsert uses ON DUPLICATE KEY UPDATE, any unique key of table is a conflict.
+func (MySQL) Upsert(table string, columns, _, update []string) string {
+	assignments := make([]string, len(update))
+	for i, column := range update {
+		assignments[i] = column + " = VALUES(" + column + ")"
+	}
+
+	if len(assignments) == 0 {
+		return "INSERT IGNORE" + strings.TrimPrefix(insert(table, columns), "INSERT")
+	}
+	return insert(table, columns) + " ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
+// SQLite is the SQLite dialect, UUIDs are stored as text and booleans as 0 or 1.
+type SQLite struct{}
+
+func (SQLite) Name() string {
+	return "sqlite"
+}
+
+func (SQLite) Rebind(query string) string {
+	return query
+}
+
+// ILike uses LIKE, it ignores the case of ASCII letters. SQLite has no default
+// escape character, the backslash is set like in the other databases.
+func (SQLite) ILike(expression string) string {
+	return expression + ` LIKE ? ESCAPE '\'`
+}
+
+// Concat uses ||, a NULL column makes the whole concatenation NULL.
+func (SQLite) Concat(columns ...string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = "coalesce(" + column + ", '')"
+	}
+	return strings.Join(parts, " || ")
+}
+
+func (SQLite) Bool(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (SQLite) UUIDType() string {
+	return "TEXT"
+}
+
+// Upsert uses the same ON CONFLICT clause as PostgreSQL.
+func (SQLite) Upsert(table string, columns, conflict, update []string) string {
+	return Postgres{}.Upsert(table, columns, conflict, update)
+}
+
+func insert(table string, columns []string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders + ")"
+}
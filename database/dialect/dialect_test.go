@@ -0,0 +1,76 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	for _, dbType := range []string{"pgx", "mysql", "sqlite"} {
+		d, err := New(dbType)
+		require.NoError(t, err)
+		assert.Equal(t, dbType, d.Name())
+	}
+
+	_, err := New("oracle")
+	assert.Error(t, err)
+}
+
+func TestDialects(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		rebind   string
+		ilike    string
+		concat   string
+		boolean  [2]string
+		uuidType string
+		upsert   string
+		insert   string
+	}{
+		{
+			dialect:  Postgres{},
+			rebind:   "SELECT * FROM users WHERE id = $1 AND email = $2",
+			ilike:    "name ILIKE ?",
+			concat:   "concat(name, address)",
+			boolean:  [2]string{"true", "false"},
+			uuidType: "UUID",
+			upsert:   "INSERT INTO t (a, b) VALUES (?, ?) ON CONFLICT (a) DO UPDATE SET b = EXCLUDED.b",
+			insert:   "INSERT INTO t (a, b) VALUES (?, ?) ON CONFLICT (a) DO NOTHING",
+		},
+		{
+			dialect:  MySQL{},
+			rebind:   "SELECT * FROM users WHERE id = ? AND email = ?",
+			ilike:    "LOWER(name) LIKE LOWER(?)",
+			concat:   "CONCAT_WS('', name, address)",
+			boolean:  [2]string{"TRUE", "FALSE"},
+			uuidType: "CHAR(36)",
+			upsert:   "INSERT INTO t (a, b) VALUES (?, ?) ON DUPLICATE KEY UPDATE b = VALUES(b)",
+			insert:   "INSERT IGNORE INTO t (a, b) VALUES (?, ?)",
+		},
+		{
+			dialect:  SQLite{},
+			rebind:   "SELECT * FROM users WHERE id = ? AND email = ?",
+			ilike:    `name LIKE ? ESCAPE '\'`,
+			concat:   "coalesce(name, '') || coalesce(address, '')",
+			boolean:  [2]string{"1", "0"},
+			uuidType: "TEXT",
+			upsert:   "INSERT INTO t (a, b) VALUES (?, ?) ON CONFLICT (a) DO UPDATE SET b = EXCLUDED.b",
+			insert:   "INSERT INTO t (a, b) VALUES (?, ?) ON CONFLICT (a) DO NOTHING",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.Name(), func(t *testing.T) {
+			d := tt.dialect
+			assert.Equal(t, tt.rebind, d.Rebind("SELECT * FROM users WHERE id = ? AND email = ?"))
+			assert.Equal(t, tt.ilike, d.ILike("name"))
+			assert.Equal(t, tt.concat, d.Concat("name", "address"))
+			assert.Equal(t, tt.boolean, [2]string{d.Bool(true), d.Bool(false)})
+			assert.Equal(t, tt.uuidType, d.UUIDType())
+			assert.Equal(t, tt.upsert, d.Upsert("t", []string{"a", "b"}, []string{"a"}, []string{"b"}))
+			assert.Equal(t, tt.insert, d.Upsert("t", []string{"a", "b"}, []string{"a"}, nil))
+		})
+	}
+}
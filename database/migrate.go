@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/database/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/rs/zerolog/log"
+)
+
+// NewMigrator func for apply the migrations embedded in the binary.
+// Each change holds the advisory lock of the database driver (pg_advisory_lock or
+// GET_LOCK, SQLite locks in the process), so replicas migrating at the same time
+// run one after the other.
+func NewMigrator(cfg *settings.Config) (*migrate.Migrate, error) {
+	src, err := migrationSource(cfg.Database.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := migrationDriver(cfg)
+	if err != nil {
+		_ = src.Close()
+		return nil, fmt.Errorf("error, not connected to database, %w", err)
+	}
+
+	return newMigrator(cfg, src, driver)
+}
+
+func newMigrator(cfg *settings.Config, src source.Driver, driver migratedb.Driver) (*migrate.Migrate, error) {
+	m, err := migrate.NewWithInstance("iofs", src, cfg.Database.Type, driver)
+	if err != nil {
+		_ = src.Close()
+		_ = driver.Close()
+		return nil, err
+	}
+	m.LockTimeout = time.Duration(cfg.Database.MigrateLockTimeoutSeconds) * time.Second
+	m.Log = migrateLogger{}
+
+	return m, nil
+}
+
+// MigrateUp func for apply the pending migrations.
+func MigrateUp(cfg *settings.Config) error {
+	m, err := NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Migrations func for list the versions of the embedded migrations, in order.
+func Migrations(dbType string) ([]uint, error) {
+	src, err := migrationSource(dbType)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	var versions []uint
+	version, err := src.First()
+	for err == nil {
+		versions = append(versions, version)
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// CheckSchema func for check that the schema is not behind the latest embedded migration.
+func (d DBConn) CheckSchema(ctx context.Context, latest uint) error {
+	var (
+		version uint
+		dirty   bool
+	)
+	err := d.Query().QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty, fix it then run migrate force", version)
+	}
+	if version < latest {
+		return fmt.Errorf("schema version %d is behind the migrations of the binary (%d)", version, latest)
+	}
+	return nil
+}
+
+func migrationSource(dbType string) (source.Driver, error) {
+	switch dbType {
+	case "pgx":
+		return iofs.New(migrations.Postgres, ".")
+	case "mysql":
+		return iofs.New(migrations.MySQL, "mysql")
+	case "sqlite":
+		return iofs.New(migrations.SQLite, "sqlite")
+	default:
+		return nil, fmt.Errorf("no migrations for database type '%v'", dbType)
+	}
+}
+
+func migrationDriver(cfg *settings.Config) (migratedb.Driver, error) {
+	var (
+		db     *sql.DB
+		dsn    string
+		driver migratedb.Driver
+		err    error
+	)
+
+	switch cfg.Database.Type {
+	case "pgx":
+		if dsn, err = utils.ConnectionURLBuilder("postgres", cfg); err != nil {
+			return nil, err
+		}
+		if db, err = sql.Open("pgx", dsn); err != nil {
+			return nil, err
+		}
+		driver, err = pgx.WithInstance(db, &pgx.Config{})
+	case "mysql":
+		if dsn, err = utils.ConnectionURLBuilder("mysql", cfg); err != nil {
+			return nil, err
+		}
+		// A migration file holds several statements.
+		if db, err = sql.Open("mysql", dsn+"&multiStatements=true"); err != nil {
+			return nil, err
+		}
+		driver, err = mysql.WithInstance(db, &mysql.Config{})
+	case "sqlite":
+		if cfg.Database.InMemory() {
+			return nil, errors.New("the in-memory database is migrated when it is opened")
+		}
+		if dsn, err = utils.ConnectionURLBuilder("sqlite", cfg); err != nil {
+			return nil, err
+		}
+		if db, err = sql.Open("sqlite", dsn); err != nil {
+			return nil, err
+		}
+		driver, err = newSQLiteDriver(db, true)
+	default:
+		return nil, fmt.Errorf("database type '%v' is not supported", cfg.Database.Type)
+	}
+
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return driver, nil
+}
+
+// migrateLogger writes the progress of the migrations to the logger.
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...interface{}) {
+	log.Info().Msgf(strings.TrimSpace(format), v...)
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
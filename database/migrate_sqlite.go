@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+)
+
+// sqliteDriver applies the migrations to SQLite. The sqlite driver of golang-migrate
+// registers modernc.org/sqlite under the same name as the GORM driver, so it can't
+// be linked in.
+type sqliteDriver struct {
+	conn *sql.Conn
+	db   *sql.DB
+	// owned closes db with the driver, it was opened for the migrations only.
+	owned  bool
+	locked atomic.Bool
+}
+
+// newSQLiteDriver func for a migration driver holding one connection of db.
+func newSQLiteDriver(db *sql.DB, owned bool) (migratedb.Driver, error) {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	driver := &sqliteDriver{conn: conn, db: db, owned: owned}
+	if err := driver.ensureVersionTable(); err != nil {
+		_ = driver.Close()
+		return nil, err
+	}
+	return driver, nil
+}
+
+func (d *sqliteDriver) Open(string) (migratedb.Driver, error) {
+	return nil, errors.New("sqlite migration driver is created by NewMigrator")
+}
+
+func (d *sqliteDriver) Close() error {
+	err := d.conn.Close()
+	if d.owned {
+		if closeErr := d.db.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Lock is held in the process only, a SQLite file is not shared by replicas.
+func (d *sqliteDriver) Lock() error {
+	if !d.locked.CompareAndSwap(false, true) {
+		return migratedb.ErrLocked
+	}
+	return nil
+}
+
+func (d *sqliteDriver) Unlock() error {
+	if !d.locked.CompareAndSwap(true, false) {
+		return migratedb.ErrNotLocked
+	}
+	return nil
+}
+
+func (d *sqliteDriver) Run(migration io.Reader) error {
+	query, err := io.ReadAll(migration)
+	if err != nil {
+		return err
+	}
+
+	return d.transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(string(query)); err != nil {
+			return migratedb.Error{OrigErr: err, Err: "migration failed", Query: query}
+		}
+		return nil
+	})
+}
+
+func (d *sqliteDriver) SetVersion(version int, dirty bool) error {
+	return d.transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM schema_migrations"); err != nil {
+			return err
+		}
+
+		// Also re-write the schema version for nil dirty versions to prevent
+		// empty schema version for failed down migration on the first migration.
+		if version >= 0 || (version == migratedb.NilVersion && dirty) {
+			_, err := tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", version, dirty)
+			return err
+		}
+		return nil
+	})
+}
+
+func (d *sqliteDriver) Version() (version int, dirty bool, err error) {
+	err = d.conn.QueryRowContext(context.Background(), "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return migratedb.NilVersion, false, nil
+	}
+	return version, dirty, err
+}
+
+func (d *sqliteDriver) Drop() error {
+	rows, err := d.conn.QueryContext(context.Background(), "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return err
+	}
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		tables = append(tables, table)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := d.conn.ExecContext(context.Background(), fmt.Sprintf("DROP TABLE IF EXISTS %q", table)); err != nil {
+			return err
+		}
+	}
+	return d.ensureVersionTable()
+}
+
+func (d *sqliteDriver) ensureVersionTable() error {
+	_, err := d.conn.ExecContext(context.Background(),
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	return err
+}
+
+func (d *sqliteDriver) transaction(fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations(t *testing.T) {
+	postgres, err := Migrations("pgx")
+	require.NoError(t, err)
+	require.NotEmpty(t, postgres)
+	assert.Equal(t, uint(1), postgres[0])
+	for i := 1; i < len(postgres); i++ {
+		assert.Less(t, postgres[i-1], postgres[i], "versions are ascending")
+	}
+
+	tests := []struct {
+		dbType  string
+		wantErr bool
+	}{
+		{"mysql", false},
+		{"sqlite", false},
+		{"oracle", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			versions, err := Migrations(tt.dbType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, postgres, versions, "every dialect has the same migrations")
+		})
+	}
+}
+
+func TestMigrateSQLiteFile(t *testing.T) {
+	cfg := &settings.Config{Database: settings.Database{
+		Type:                      "sqlite",
+		Name:                      filepath.Join(t.TempDir(), "test.db"),
+		MigrateLockTimeoutSeconds: 5,
+	}}
+	ctx := context.Background()
+
+	versions, err := Migrations("sqlite")
+	require.NoError(t, err)
+	latest := versions[len(versions)-1]
+
+	require.NoError(t, MigrateUp(cfg))
+	require.NoError(t, MigrateUp(cfg), "nothing to apply is not an error")
+
+	conn, err := NewDBConnection(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Query().Close() })
+	require.NoError(t, conn.CheckSchema(ctx, latest))
+
+	var users int
+	require.NoError(t, conn.Query().Get(&users, "SELECT COUNT(*) FROM users"))
+	assert.Positive(t, users, "the first migration seeds the users")
+
+	m, err := NewMigrator(cfg)
+	require.NoError(t, err)
+	require.NoError(t, m.Steps(-1))
+	assert.ErrorContains(t, conn.CheckSchema(ctx, latest), "is behind the migrations")
+
+	require.NoError(t, m.Down())
+	_, _ = m.Close()
+	_, err = conn.Query().Exec("SELECT 1 FROM users")
+	assert.Error(t, err, "the down migrations drop the tables")
+
+	require.NoError(t, MigrateUp(cfg))
+	require.NoError(t, conn.CheckSchema(ctx, latest))
+
+	_, err = conn.Query().Exec("UPDATE schema_migrations SET dirty = 1")
+	require.NoError(t, err)
+	assert.ErrorContains(t, conn.CheckSchema(ctx, latest), "is dirty")
+}
+
+func TestMigrateInMemory(t *testing.T) {
+	conn, err := NewMemoryConnection()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Query().Close() })
+
+	versions, err := Migrations("sqlite")
+	require.NoError(t, err)
+	assert.NoError(t, conn.CheckSchema(context.Background(), versions[len(versions)-1]), "the in-memory database is migrated when opened")
+
+	err = MigrateUp(&settings.Config{Database: settings.Database{Type: "sqlite", Name: ":memory:", MigrateLockTimeoutSeconds: 5}})
+	assert.Error(t, err, "another connection would migrate another database")
+}
@@ -0,0 +1,13 @@
+-- Create idempotency keys table, responses replayed for retried requests
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR (64) PRIMARY KEY,
+    fingerprint VARCHAR (64) NOT NULL,
+    completed boolean NOT NULL DEFAULT false,
+    status INT NOT NULL DEFAULT 0,
+    content_type VARCHAR (255) NOT NULL DEFAULT '',
+    body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW (),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,19 @@
+// Package migrations embeds the SQL migrations into the binary, see database.NewMigrator.
+package migrations
+
+import "embed"
+
+// Postgres holds the PostgreSQL migrations.
+//
+//go:embed *.sql
+var Postgres embed.FS
+
+// MySQL holds the MySQL migrations.
+//
+//go:embed mysql/*.sql
+var MySQL embed.FS
+
+// SQLite holds the SQLite migrations.
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func files(t *testing.T, fsys embed.FS, dir string) []string {
+	t.Helper()
+
+	matches, err := fs.Glob(fsys, dir+"/*.sql")
+	require.NoError(t, err)
+
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = path.Base(match)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestSetsAreParallel checks that every database has the same migrations, up and down.
+func TestSetsAreParallel(t *testing.T) {
+	postgres := files(t, Postgres, ".")
+	require.NotEmpty(t, postgres)
+	for i := 0; i < len(postgres); i += 2 {
+		down, up := postgres[i], postgres[i+1]
+		assert.Equal(t, strings.TrimSuffix(down, ".down.sql"), strings.TrimSuffix(up, ".up.sql"), "every migration has an up and a down file")
+	}
+
+	for name, set := range map[string][]string{
+		"mysql":  files(t, MySQL, "mysql"),
+		"sqlite": files(t, SQLite, "sqlite"),
+	} {
+		assert.Equal(t, postgres, set, name)
+	}
+}
@@ -0,0 +1,30 @@
+-- Create users table
+CREATE TABLE `users` (
+    `id` CHAR(36) NOT NULL PRIMARY KEY,
+    `username` VARCHAR(255) NOT NULL UNIQUE,
+    `email` VARCHAR(255) NOT NULL UNIQUE,
+    `password` VARCHAR(255) NOT NULL,
+    `role_id` VARCHAR(36) NOT NULL,
+    `status` INT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `created_by` VARCHAR(100),
+    `updated_at` DATETIME NULL,
+    `updated_by` VARCHAR(100),
+    `is_deleted` BOOLEAN DEFAULT false
+);
+
+-- Create books authors
+CREATE TABLE `authors` (
+    `id` CHAR(36) NOT NULL PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL,
+    `address` TEXT,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `created_by` VARCHAR(100),
+    `updated_at` DATETIME NULL,
+    `updated_by` VARCHAR(100),
+    `is_deleted` BOOLEAN DEFAULT false
+);
+
+INSERT INTO `users` (
+    `id`, `username`, `email`, `password`, `role_id`, `status`, `created_at`, `updated_at`)
+    VALUES (UUID(), 'admin', 'admin@gmail.com', '$2a$10$3lQxgep/NIdg.ibH5Ydeo.yt3P6MSLATdAbvgoiz37KGGchVMY6.G', 'admin', 1, NOW(), NOW());
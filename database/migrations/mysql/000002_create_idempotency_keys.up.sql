@@ -0,0 +1,13 @@
+-- Create idempotency keys table, responses replayed for retried requests
+CREATE TABLE `idempotency_keys` (
+    `idempotency_key` VARCHAR(64) NOT NULL PRIMARY KEY,
+    `fingerprint` VARCHAR(64) NOT NULL,
+    `completed` BOOLEAN NOT NULL DEFAULT false,
+    `status` INT NOT NULL DEFAULT 0,
+    `content_type` VARCHAR(255) NOT NULL DEFAULT '',
+    `body` LONGBLOB,
+    `created_at` DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    `expires_at` DATETIME(6) NOT NULL
+);
+
+CREATE INDEX `idempotency_keys_expires_at_idx` ON `idempotency_keys` (`expires_at`);
@@ -0,0 +1,3 @@
+-- Delete tables
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS authors;
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- Create users table
+CREATE TABLE users (
+    id TEXT NOT NULL PRIMARY KEY,
+    username VARCHAR (255) NOT NULL UNIQUE,
+    email VARCHAR (255) NOT NULL UNIQUE,
+    password VARCHAR (255) NOT NULL,
+    role_id VARCHAR (36) NOT NULL,
+    status INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR (100),
+    updated_at DATETIME NULL,
+    updated_by VARCHAR (100),
+    is_deleted BOOLEAN DEFAULT 0
+);
+
+-- Create books authors
+CREATE TABLE authors (
+    id TEXT NOT NULL PRIMARY KEY,
+    name VARCHAR (255) NOT NULL,
+    address TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    created_by VARCHAR (100),
+    updated_at DATETIME NULL,
+    updated_by VARCHAR (100),
+    is_deleted BOOLEAN DEFAULT 0
+);
+
+-- SQLite has no UUID function, the id is a random version 4 UUID.
+INSERT INTO users (
+    id, username, email, password, role_id, status, created_at, updated_at)
+    VALUES (
+        lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+            substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+        'admin', 'admin@gmail.com', '$2a$10$3lQxgep/NIdg.ibH5Ydeo.yt3P6MSLATdAbvgoiz37KGGchVMY6.G', 'admin', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
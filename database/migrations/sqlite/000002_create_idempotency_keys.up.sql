@@ -0,0 +1,13 @@
+-- Create idempotency keys table, responses replayed for retried requests
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR (64) NOT NULL PRIMARY KEY,
+    fingerprint VARCHAR (64) NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT 0,
+    status INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR (255) NOT NULL DEFAULT '',
+    body BLOB,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -2,12 +2,11 @@ package database
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"strconv"
 	"time"
 
+	"github.com/fiber-go-template/config/settings"
 	"github.com/fiber-go-template/config/utils"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 
@@ -17,53 +16,51 @@ import (
 )
 
 // MysqlConnection func for connection to Mysql database.
-func MysqlConnection() (*sqlx.DB, error) {
-	// Define database connection settings.
-	maxConn, _ := strconv.Atoi(os.Getenv("DB_MAX_CONNECTIONS"))
-	maxIdleConn, _ := strconv.Atoi(os.Getenv("DB_MAX_IDLE_CONNECTIONS"))
-	maxLifetimeConn, _ := strconv.Atoi(os.Getenv("DB_MAX_LIFETIME_CONNECTIONS"))
-
+func MysqlConnection(cfg *settings.Config) (*sqlx.DB, error) {
 	// Build Mysql connection URL.
-	mysqlConnURL, err := utils.ConnectionURLBuilder("mysql")
+	mysqlConnURL, err := utils.ConnectionURLBuilder("mysql", cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// Define database connection for Mysql.
-	db, err := sqlx.Connect("mysql", mysqlConnURL)
+	sqlDB, err := openTracedDB("mysql", mysqlConnURL, semconv.DBSystemMySQL)
 	if err != nil {
 		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
+	db := sqlx.NewDb(sqlDB, "mysql")
 
 	// Set database connection settings:
 	// 	- SetMaxOpenConns: the default is 0 (unlimited)
 	// 	- SetMaxIdleConns: defaultMaxIdleConns = 2
 	// 	- SetConnMaxLifetime: 0, connections are reused forever
-	db.SetMaxOpenConns(maxConn)
-	db.SetMaxIdleConns(maxIdleConn)
-	db.SetConnMaxLifetime(time.Duration(maxLifetimeConn))
-
-	// Try to ping database.
-	if err := db.Ping(); err != nil {
-		defer db.Close() // close database connection
-		return nil, fmt.Errorf("error, not sent ping to database, %w", err)
-	}
+	db.SetMaxOpenConns(cfg.Database.MaxConnections)
+	db.SetMaxIdleConns(cfg.Database.MaxIdleConnections)
+	db.SetConnMaxLifetime(time.Duration(cfg.Database.MaxLifetimeConnections) * time.Second)
 
+	// The connection is checked by NewDBConnection and the readiness probe,
+	// the pool reconnects once the database is reachable.
 	return db, nil
 }
 
-func GormMysqlConnection() (*gorm.DB, error) {
+func GormMysqlConnection(cfg *settings.Config) (*gorm.DB, error) {
 	// Build Gorm MySQL connection URL.
-	dsn, err := utils.ConnectionURLBuilder("mysql")
+	dsn, err := utils.ConnectionURLBuilder("mysql", cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	sqlDB, err := openTracedDB("mysql", dsn, semconv.DBSystemMySQL)
+	if err != nil {
+		return nil, err
+	}
 
+	// The server version is not queried, so the server starts while MySQL is down.
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		DisableAutomaticPing: true,
+	})
 	if err != nil {
-		log.Fatal("Failed to connect to database. \n", err)
-		os.Exit(2)
+		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
 
 	return db, nil
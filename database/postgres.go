@@ -2,12 +2,11 @@ package database
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"strconv"
 	"time"
 
+	"github.com/fiber-go-template/config/settings"
 	"github.com/fiber-go-template/config/utils"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -17,55 +16,51 @@ import (
 )
 
 // PostgreSQLConnection func for connection to PostgreSQL database.
-func PostgreSQLConnection() (*sqlx.DB, error) {
-	// Define database connection settings.
-	maxConn, _ := strconv.Atoi(os.Getenv("DB_MAX_CONNECTIONS"))
-	maxIdleConn, _ := strconv.Atoi(os.Getenv("DB_MAX_IDLE_CONNECTIONS"))
-	maxLifetimeConn, _ := strconv.Atoi(os.Getenv("DB_MAX_LIFETIME_CONNECTIONS"))
-
+func PostgreSQLConnection(cfg *settings.Config) (*sqlx.DB, error) {
 	// Build PostgreSQL connection URL.
-	postgresConnURL, err := utils.ConnectionURLBuilder("postgres")
+	postgresConnURL, err := utils.ConnectionURLBuilder("postgres", cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// Define database connection for PostgreSQL.
-	db, err := sqlx.Connect("pgx", postgresConnURL)
+	sqlDB, err := openTracedDB("pgx", postgresConnURL, semconv.DBSystemPostgreSQL)
 	if err != nil {
 		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
+	db := sqlx.NewDb(sqlDB, "pgx")
 
 	// Set database connection settings:
 	// 	- SetMaxOpenConns: the default is 0 (unlimited)
 	// 	- SetMaxIdleConns: defaultMaxIdleConns = 2
 	// 	- SetConnMaxLifetime: 0, connections are reused forever
-	db.SetMaxOpenConns(maxConn)
-	db.SetMaxIdleConns(maxIdleConn)
-	db.SetConnMaxLifetime(time.Duration(maxLifetimeConn))
-
-	// Try to ping database.
-	if err := db.Ping(); err != nil {
-		defer db.Close() // close database connection
-		return nil, fmt.Errorf("error, not sent ping to database, %w", err)
-	}
+	db.SetMaxOpenConns(cfg.Database.MaxConnections)
+	db.SetMaxIdleConns(cfg.Database.MaxIdleConnections)
+	db.SetConnMaxLifetime(time.Duration(cfg.Database.MaxLifetimeConnections) * time.Second)
 
+	// The connection is checked by NewDBConnection and the readiness probe,
+	// the pool reconnects once the database is reachable.
 	return db, nil
 }
 
-func GormPostgreSQLConnection() (*gorm.DB, error) {
+func GormPostgreSQLConnection(cfg *settings.Config) (*gorm.DB, error) {
 	// Build Gorm PostgreSQL connection URL.
-	dsn, err := utils.ConnectionURLBuilder("postgres")
+	dsn, err := utils.ConnectionURLBuilder("postgres", cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	sqlDB, err := openTracedDB("pgx", dsn, semconv.DBSystemPostgreSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
 		// Logger: logger.Default.LogMode(logger.Info),
+		DisableAutomaticPing: true,
 	})
-
-	// Try to ping database.
 	if err != nil {
-		log.Fatal("Failed to connect to database. \n", err)
+		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
 
 	return db, nil
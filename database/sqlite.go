@@ -0,0 +1,109 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/fiber-go-template/config/utils"
+	"github.com/glebarez/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"gorm.io/gorm"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	// sqlx only knows the placeholders of the cgo driver name (sqlite3).
+	sqlx.BindDriver("sqlite", sqlx.QUESTION)
+}
+
+// SQLiteConnection func for connection to SQLite database, with a pure-Go driver.
+// An in-memory database starts empty, the embedded migrations are applied to it.
+func SQLiteConnection(cfg *settings.Config) (*sqlx.DB, error) {
+	// Build SQLite connection URL.
+	dsn, err := utils.ConnectionURLBuilder("sqlite", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Define database connection for SQLite.
+	sqlDB, err := openTracedDB("sqlite", dsn, semconv.DBSystemSqlite)
+	if err != nil {
+		return nil, fmt.Errorf("error, not connected to database, %w", err)
+	}
+	db := sqlx.NewDb(sqlDB, "sqlite")
+
+	if !cfg.Database.InMemory() {
+		db.SetMaxOpenConns(cfg.Database.MaxConnections)
+		db.SetMaxIdleConns(cfg.Database.MaxIdleConnections)
+		db.SetConnMaxLifetime(time.Duration(cfg.Database.MaxLifetimeConnections) * time.Second)
+		return db, nil
+	}
+
+	// Each connection opens its own in-memory database, so the pool keeps a single
+	// connection forever.
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	if err := migrateInMemory(cfg, db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error, not migrated in-memory database, %w", err)
+	}
+
+	return db, nil
+}
+
+// GormSQLiteConnection func for GORM over the sqlx pool of SQLiteConnection. SQLite has
+// a single writer and an in-memory database lives in one connection, so both share it.
+func GormSQLiteConnection(db *sqlx.DB) (*gorm.DB, error) {
+	orm, err := gorm.Open(sqlite.Dialector{Conn: db.DB}, &gorm.Config{
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error, not connected to database, %w", err)
+	}
+
+	return orm, nil
+}
+
+// NewMemoryConnection func for opening an empty, migrated in-memory SQLite database,
+// e.g. for testing repositories and services without any database server.
+func NewMemoryConnection() (DBConn, error) {
+	return NewDBConnection(&settings.Config{
+		Database: settings.Database{
+			Type:                      "sqlite",
+			Name:                      ":memory:",
+			MigrateLockTimeoutSeconds: 60,
+		},
+	})
+}
+
+func migrateInMemory(cfg *settings.Config, db *sqlx.DB) error {
+	src, err := migrationSource(cfg.Database.Type)
+	if err != nil {
+		return err
+	}
+
+	driver, err := newSQLiteDriver(db.DB, false)
+	if err != nil {
+		_ = src.Close()
+		return err
+	}
+
+	m, err := newMigrator(cfg, src, driver)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	// The progress of every new database is not worth logging.
+	m.Log = nil
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
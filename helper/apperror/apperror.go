@@ -0,0 +1,163 @@
+package apperror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Stable error codes returned in the "code" member of problem responses.
+const (
+	CodeBadRequest   = "BAD_REQUEST"
+	CodeValidation   = "VALIDATION_FAILED"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeTooMany      = "TOO_MANY_REQUESTS"
+	CodeInternal     = "INTERNAL_ERROR"
+)
+
+// FieldError describes why a single request field was rejected.
+// Message is the English message, used as the i18n catalog key, formatted with Args.
+type FieldError struct {
+	Field   string        `json:"field"`
+	Message string        `json:"message"`
+	Args    []interface{} `json:"-"`
+}
+
+// Error is a typed application error carrying its HTTP status and stable code.
+// Message is the English message, used as the i18n catalog key, formatted with Args.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Args    []interface{}
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if len(e.Args) > 0 {
+		message = fmt.Sprintf(e.Message, e.Args...)
+	}
+
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithField adds a field detail to the error, its message formatted with args when given.
+func (e *Error) WithField(field, message string, args ...interface{}) *Error {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message, Args: args})
+	return e
+}
+
+// WithFields adds field details from a field name to message map, sorted by field.
+func (e *Error) WithFields(fields map[string]string) *Error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		e.Fields = append(e.Fields, FieldError{Field: name, Message: fields[name]})
+	}
+	return e
+}
+
+// BadRequest is returned for malformed input such as an invalid UUID or body.
+func BadRequest(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusBadRequest, Code: CodeBadRequest, Message: message, Args: args}
+}
+
+// Validation is returned when a well-formed request fails validation rules.
+func Validation(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusUnprocessableEntity, Code: CodeValidation, Message: message, Args: args}
+}
+
+// Unauthorized is returned when the caller is not authenticated.
+func Unauthorized(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusUnauthorized, Code: CodeUnauthorized, Message: message, Args: args}
+}
+
+// Forbidden is returned when the caller is authenticated but not allowed.
+func Forbidden(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusForbidden, Code: CodeForbidden, Message: message, Args: args}
+}
+
+// NotFound is returned when the requested resource does not exist.
+func NotFound(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusNotFound, Code: CodeNotFound, Message: message, Args: args}
+}
+
+// Conflict is returned when the request conflicts with the current state, e.g. a duplicate key.
+func Conflict(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusConflict, Code: CodeConflict, Message: message, Args: args}
+}
+
+// TooManyRequests is returned when the caller exceeded its rate limit.
+func TooManyRequests(message string, args ...interface{}) *Error {
+	return &Error{Status: fiber.StatusTooManyRequests, Code: CodeTooMany, Message: message, Args: args}
+}
+
+// Internal wraps an unexpected error. Its cause is logged but never sent to the client.
+func Internal(err error) *Error {
+	return &Error{Status: fiber.StatusInternalServerError, Code: CodeInternal, Message: "internal server error", Err: err}
+}
+
+// As returns the typed error in err's chain, if any.
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// FromDatabase translates errors returned by sqlx and GORM into typed errors.
+func FromDatabase(err error, notFoundMessage string) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := As(err); ok {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows) {
+		return NotFound(notFoundMessage)
+	}
+
+	// PostgreSQL unique_violation
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) && pgErr.SQLState() == "23505" {
+		return &Error{Status: fiber.StatusConflict, Code: CodeConflict, Message: "data already exists", Err: err}
+	}
+
+	// MySQL ER_DUP_ENTRY
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		return &Error{Status: fiber.StatusConflict, Code: CodeConflict, Message: "data already exists", Err: err}
+	}
+
+	// SQLite SQLITE_CONSTRAINT_PRIMARYKEY and SQLITE_CONSTRAINT_UNIQUE
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code() == 1555 || sqliteErr.Code() == 2067) {
+		return &Error{Status: fiber.StatusConflict, Code: CodeConflict, Message: "data already exists", Err: err}
+	}
+
+	return Internal(err)
+}
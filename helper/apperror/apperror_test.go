@@ -0,0 +1,134 @@
+package apperror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type pgError string
+
+func (e pgError) Error() string    { return "pg: " + string(e) }
+func (e pgError) SQLState() string { return string(e) }
+
+type sqliteError int
+
+func (e sqliteError) Error() string { return fmt.Sprintf("sqlite: %d", int(e)) }
+func (e sqliteError) Code() int     { return int(e) }
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{"message", NotFound("author is not found"), "NOT_FOUND: author is not found"},
+		{"formatted", BadRequest("invalid query parameter: %s", "pageSize"), "BAD_REQUEST: invalid query parameter: pageSize"},
+		{"wrapped", Internal(sql.ErrConnDone), "INTERNAL_ERROR: internal server error: sql: connection is already closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.err, tt.want)
+		})
+	}
+
+	assert.ErrorIs(t, Internal(sql.ErrConnDone), sql.ErrConnDone)
+	withFields := Validation("request validation failed").WithFields(map[string]string{"name": "required", "address": "too long"})
+	assert.Equal(t, []FieldError{{Field: "address", Message: "too long"}, {Field: "name", Message: "required"}}, withFields.Fields)
+}
+
+func TestFromDatabase(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"sql no rows", sql.ErrNoRows, fiber.StatusNotFound},
+		{"gorm not found", fmt.Errorf("find: %w", gorm.ErrRecordNotFound), fiber.StatusNotFound},
+		{"postgres unique violation", pgError("23505"), fiber.StatusConflict},
+		{"postgres other", pgError("23503"), fiber.StatusInternalServerError},
+		{"mysql duplicate entry", &mysql.MySQLError{Number: 1062}, fiber.StatusConflict},
+		{"sqlite primary key", sqliteError(1555), fiber.StatusConflict},
+		{"sqlite unique", sqliteError(2067), fiber.StatusConflict},
+		{"sqlite other", sqliteError(19), fiber.StatusInternalServerError},
+		{"typed error is kept", Forbidden("not yours"), fiber.StatusForbidden},
+		{"other", errors.New("connection refused"), fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr, ok := As(FromDatabase(tt.err, "not found"))
+			require.True(t, ok)
+			assert.Equal(t, tt.status, appErr.Status)
+		})
+	}
+
+	assert.NoError(t, FromDatabase(nil, "not found"))
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		handler fiber.Handler
+		lang    string
+		want    Problem
+	}{
+		{"typed error", fiber.MethodGet, func(c *fiber.Ctx) error {
+			return BadRequest("invalid filter").WithField("name", "field is not filterable")
+		}, "en", Problem{Title: "Bad Request", Status: 400, Detail: "invalid filter", Code: CodeBadRequest,
+			Errors: []FieldError{{Field: "name", Message: "field is not filterable"}}}},
+		{"translated", fiber.MethodGet, func(c *fiber.Ctx) error {
+			return BadRequest("invalid filter").WithField("name", "field is not filterable")
+		}, "id", Problem{Title: "Permintaan Tidak Valid", Status: 400, Detail: "filter tidak valid", Code: CodeBadRequest,
+			Errors: []FieldError{{Field: "name", Message: "field tidak dapat difilter"}}}},
+		{"field message with args", fiber.MethodGet, func(c *fiber.Ctx) error {
+			return BadRequest("invalid filter").WithField("name", "operator '%s' is not supported", "gt")
+		}, "en", Problem{Title: "Bad Request", Status: 400, Detail: "invalid filter", Code: CodeBadRequest,
+			Errors: []FieldError{{Field: "name", Message: "operator 'gt' is not supported"}}}},
+		{"translated field message with args", fiber.MethodGet, func(c *fiber.Ctx) error {
+			return BadRequest("invalid filter").WithField("age", "'%s' is not a number", "old")
+		}, "id", Problem{Title: "Permintaan Tidak Valid", Status: 400, Detail: "filter tidak valid", Code: CodeBadRequest,
+			Errors: []FieldError{{Field: "age", Message: "'old' bukan angka"}}}},
+		{"plain error hides its message", fiber.MethodGet, func(c *fiber.Ctx) error {
+			return errors.New("dial tcp: connection refused")
+		}, "en", Problem{Title: "Internal Server Error", Status: 500, Detail: "internal server error", Code: CodeInternal}},
+		{"fiber error", fiber.MethodPost, nil, "en", Problem{Title: "Method Not Allowed", Status: 405, Detail: "Method Not Allowed", Code: CodeBadRequest}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			if tt.handler != nil {
+				app.Get("/", tt.handler)
+			} else {
+				app.Get("/", func(c *fiber.Ctx) error { return nil })
+			}
+
+			req := httptest.NewRequest(tt.method, "/?lang="+tt.lang, nil)
+			req.Header.Set(fiber.HeaderXRequestID, "trace-1")
+			res, err := app.Test(req, -1)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.want.Status, res.StatusCode)
+			assert.Equal(t, ContentTypeProblem, res.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, "trace-1", res.Header.Get(fiber.HeaderXRequestID))
+
+			var problem Problem
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&problem))
+			tt.want.Type, tt.want.Instance, tt.want.TraceID = "about:blank", "/?lang="+tt.lang, "trace-1"
+			assert.Equal(t, tt.want, problem)
+		})
+	}
+}
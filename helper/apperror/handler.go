@@ -0,0 +1,116 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fiber-go-template/config/logger"
+	"github.com/fiber-go-template/helper/i18n"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ContentTypeProblem is the media type of RFC 7807 responses.
+const ContentTypeProblem = "application/problem+json"
+
+// Problem is the RFC 7807 body returned for every error.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	TraceID  string       `json:"traceId,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// ErrorHandler is the Fiber error handler rendering every returned error as a Problem.
+// See: https://docs.gofiber.io/guide/error-handling
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	appErr := toError(err)
+
+	traceID := TraceID(c)
+	if appErr.Status >= fiber.StatusInternalServerError {
+		logger.ErrorWithStack(c.UserContext(), err)
+	}
+
+	// Messages are sent in the locale of the request.
+	locale := i18n.Locale(c)
+	fields := make([]FieldError, 0, len(appErr.Fields))
+	for _, field := range appErr.Fields {
+		fields = append(fields, FieldError{Field: field.Field, Message: i18n.Translate(locale, field.Message, field.Args...)})
+	}
+
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    i18n.Translate(locale, http.StatusText(appErr.Status)),
+		Status:   appErr.Status,
+		Detail:   i18n.Translate(locale, appErr.Message, appErr.Args...),
+		Instance: c.OriginalURL(),
+		Code:     appErr.Code,
+		TraceID:  traceID,
+		Errors:   fields,
+	}
+
+	c.Set(fiber.HeaderXRequestID, traceID)
+	if err := c.Status(appErr.Status).JSON(problem); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, ContentTypeProblem)
+
+	return nil
+}
+
+// TraceID returns the request ID set by the request ID middleware, the one sent by
+// the client, or a new one.
+func TraceID(c *fiber.Ctx) string {
+	if id := logger.RequestID(c.UserContext()); id != "" {
+		return id
+	}
+	if id := c.Get(fiber.HeaderXRequestID); id != "" {
+		return id
+	}
+	if id := c.GetRespHeader(fiber.HeaderXRequestID); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+func toError(err error) *Error {
+	if appErr, ok := As(err); ok {
+		return appErr
+	}
+
+	// Errors raised by Fiber itself, e.g. 404 route, 405 method or 413 body limit.
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return &Error{Status: fiberErr.Code, Code: codeOf(fiberErr.Code), Message: fiberErr.Message}
+	}
+
+	return Internal(err)
+}
+
+func codeOf(status int) string {
+	switch status {
+	case fiber.StatusBadRequest:
+		return CodeBadRequest
+	case fiber.StatusUnprocessableEntity:
+		return CodeValidation
+	case fiber.StatusUnauthorized:
+		return CodeUnauthorized
+	case fiber.StatusForbidden:
+		return CodeForbidden
+	case fiber.StatusNotFound:
+		return CodeNotFound
+	case fiber.StatusConflict:
+		return CodeConflict
+	case fiber.StatusTooManyRequests:
+		return CodeTooMany
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
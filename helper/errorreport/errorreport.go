@@ -0,0 +1,195 @@
+package errorreport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fiber-go-template/config/settings"
+	"github.com/rs/zerolog/log"
+)
+
+// Event levels.
+const (
+	LevelPanic = "fatal"
+	LevelError = "error"
+)
+
+// Event is a panic or server error reported to the sinks, scrubbed of PII.
+type Event struct {
+	ID          string    `json:"id"`
+	Time        time.Time `json:"time"`
+	Level       string    `json:"level"`
+	Message     string    `json:"message"`
+	Stack       string    `json:"stack,omitempty"`
+	Environment string    `json:"environment,omitempty"`
+	RequestID   string    `json:"requestId,omitempty"`
+	Method      string    `json:"method,omitempty"`
+	Route       string    `json:"route,omitempty"`
+	Path        string    `json:"path,omitempty"`
+	Status      int       `json:"status,omitempty"`
+	UserID      string    `json:"userId,omitempty"`
+}
+
+// maxMessages bounds the distinct messages counted in a minute, the events of other
+// messages are dropped until the next minute.
+const maxMessages = 1000
+
+// Sink receives the reported events.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+	Close() error
+}
+
+// Reporter sends events to its sinks in the background. Each distinct message is
+// sent at most RatePerMinute times a minute, the other events are dropped.
+// Events reported after Close are dropped.
+type Reporter struct {
+	sinks         []Sink
+	ratePerMinute int
+	events        chan Event
+	done          chan struct{}
+
+	// mu guards closed and the counts of the current minute.
+	mu     sync.Mutex
+	closed bool
+	minute time.Time
+	counts map[string]int
+}
+
+func NewReporter(ratePerMinute int, sinks ...Sink) *Reporter {
+	reporter := &Reporter{
+		sinks:         sinks,
+		ratePerMinute: ratePerMinute,
+		events:        make(chan Event, 100),
+		done:          make(chan struct{}),
+		counts:        map[string]int{},
+	}
+	go reporter.run()
+	return reporter
+}
+
+// Report scrubs and queues event, it never blocks the request.
+func (r *Reporter) Report(event Event) {
+	if r == nil || len(r.sinks) == 0 {
+		return
+	}
+
+	if event.ID == "" {
+		event.ID = newEventID()
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	event.Message = Scrub(event.Message)
+	event.Stack = Scrub(event.Stack)
+	event.Path = Scrub(event.Path)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Sending on the closed queue would panic.
+	if r.closed || !r.allow(event.Message, time.Now()) {
+		return
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		log.Warn().Str("eventId", event.ID).Msg("Error report queue is full, event dropped.")
+	}
+}
+
+// allow counts message in the current minute, r.mu must be held.
+func (r *Reporter) allow(message string, now time.Time) bool {
+	if now.Sub(r.minute) >= time.Minute {
+		r.minute, r.counts = now, map[string]int{}
+	}
+
+	count, seen := r.counts[message]
+	if count >= r.ratePerMinute || (!seen && len(r.counts) >= maxMessages) {
+		return false
+	}
+	r.counts[message] = count + 1
+	return true
+}
+
+// Close sends the queued events and closes the sinks.
+func (r *Reporter) Close(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	var err error
+	for _, sink := range r.sinks {
+		if closeErr := sink.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (r *Reporter) run() {
+	defer close(r.done)
+
+	for event := range r.events {
+		for _, sink := range r.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := sink.Send(ctx, event); err != nil {
+				log.Warn().Err(err).Str("eventId", event.ID).Msg("Failed to report the error.")
+			}
+			cancel()
+		}
+	}
+}
+
+var (
+	defaultReporter *Reporter
+	environment     string
+)
+
+// Init creates the reporter used by Report, with the Sentry DSN and file sinks
+// configured, limited to RatePerMinute events a minute per message.
+// Events are tagged with the environment, e.g. the STAGE_STATUS. The returned func flushes the sinks.
+func Init(cfg settings.ErrorReport, env string) (shutdown func(context.Context) error, err error) {
+	var sinks []Sink
+	if cfg.SentryDSN != "" {
+		sink, err := NewSentrySink(cfg.SentryDSN)
+		if err != nil {
+			return func(context.Context) error { return nil }, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.File != "" {
+		sink, err := NewFileSink(cfg.File)
+		if err != nil {
+			return func(context.Context) error { return nil }, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	environment = env
+	defaultReporter = NewReporter(cfg.RatePerMinute, sinks...)
+	return defaultReporter.Close, nil
+}
+
+// Report sends event to the sinks configured by Init, if any.
+func Report(event Event) {
+	if event.Environment == "" {
+		event.Environment = environment
+	}
+	defaultReporter.Report(event)
+}
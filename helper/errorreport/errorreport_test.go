@@ -0,0 +1,126 @@
+package errorreport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySink collects the sent events.
+type memorySink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *memorySink) Send(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func (s *memorySink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]string, len(s.events))
+	for i, event := range s.events {
+		result[i] = event.Message
+	}
+	return result
+}
+
+func TestReporterRateLimit(t *testing.T) {
+	sink := &memorySink{}
+	reporter := NewReporter(2, sink)
+
+	for _, message := range []string{"a", "a", "a", "b"} {
+		reporter.Report(Event{Message: message})
+	}
+	require.NoError(t, reporter.Close(context.Background()))
+
+	assert.Equal(t, []string{"a", "a", "b"}, sink.messages())
+}
+
+func TestReporterAllow(t *testing.T) {
+	now := time.Now()
+	reporter := &Reporter{ratePerMinute: 1, counts: map[string]int{}}
+
+	for i := 0; i < maxMessages; i++ {
+		require.True(t, reporter.allow(fmt.Sprint(i), now))
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		now     time.Time
+		want    bool
+	}{
+		{"over the rate", "0", now, false},
+		{"over the distinct messages", "new", now, false},
+		{"next minute resets the counts", "new", now.Add(time.Minute), true},
+		{"known message in the next minute", "0", now.Add(time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reporter.allow(tt.message, tt.now))
+			assert.LessOrEqual(t, len(reporter.counts), maxMessages)
+		})
+	}
+}
+
+func TestReporterReportAfterClose(t *testing.T) {
+	sink := &memorySink{}
+	reporter := NewReporter(10, sink)
+	require.NoError(t, reporter.Close(context.Background()))
+
+	assert.NotPanics(t, func() { reporter.Report(Event{Message: "late"}) })
+	require.NoError(t, reporter.Close(context.Background()))
+	assert.Empty(t, sink.messages())
+}
+
+func TestReporterConcurrentClose(t *testing.T) {
+	reporter := NewReporter(1000, &memorySink{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				reporter.Report(Event{Message: fmt.Sprint(i, j)})
+			}
+		}(i)
+	}
+	require.NoError(t, reporter.Close(context.Background()))
+	wg.Wait()
+}
+
+func TestScrub(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"jwt", "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig expired", "token [jwt] expired"},
+		{"bearer", "header Bearer abc.def", "header Bearer [filtered]"},
+		{"password field", `{"password":"hunter2"}`, `{"password":"[filtered]"}`},
+		{"query token", "/reset?token=abc&x=1", "/reset?token=[filtered]&x=1"},
+		{"email", "no user john.doe@example.com", "no user [email]"},
+		{"card number", "card 4111 1111 1111 1111 declined", "card [number] declined"},
+		{"plain", "sql: no rows in result set", "sql: no rows in result set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Scrub(tt.value))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package errorreport
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+)
+
+// scrubRules replace the personal data and secrets found in messages, stacks and paths.
+var scrubRules = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// JWTs
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), "[jwt]"},
+	// Authorization: Bearer xxx
+	{regexp.MustCompile(`(?i)(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`), "$1 [filtered]"},
+	// password=xxx, "token": "xxx"...
+	{regexp.MustCompile(`(?i)("?(?:password|passwd|secret|token|refresh_token|api_key|apikey|authorization)"?\s*[:=]\s*"?)[^"&\s,}]+`), "${1}[filtered]"},
+	// e-mail addresses
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[email]"},
+	// card numbers
+	{regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), "[number]"},
+}
+
+// Scrub replaces the personal data and secrets of value: JWTs, credentials, e-mail
+// addresses and card numbers.
+func Scrub(value string) string {
+	for _, rule := range scrubRules {
+		value = rule.pattern.ReplaceAllString(value, rule.replacement)
+	}
+	return value
+}
+
+// newEventID returns a random 32 hex characters ID, the format of Sentry event IDs.
+func newEventID() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,108 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SentrySink sends the events to the store endpoint of a Sentry compatible server
+// (Sentry, GlitchTip...) described by a DSN, https://<key>@<host>/<project>.
+type SentrySink struct {
+	Client   *http.Client
+	endpoint string
+	auth     string
+}
+
+func NewSentrySink(dsn string) (*SentrySink, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+
+	project := strings.Trim(parsed.Path, "/")
+	if parsed.User == nil || parsed.User.Username() == "" || project == "" {
+		return nil, fmt.Errorf("invalid sentry DSN: the public key and project are required")
+	}
+
+	// The project is the last path segment, Sentry may be served under a path.
+	prefix := ""
+	if i := strings.LastIndex(project, "/"); i >= 0 {
+		prefix, project = "/"+project[:i], project[i+1:]
+	}
+
+	return &SentrySink{
+		Client:   &http.Client{Timeout: 5 * time.Second},
+		endpoint: fmt.Sprintf("%s://%s%s/api/%s/store/", parsed.Scheme, parsed.Host, prefix, project),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=fiber-go-template/1.0, sentry_key=%s",
+			parsed.User.Username()),
+	}, nil
+}
+
+// sentryEvent is the subset of the Sentry event payload sent.
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Environment string            `json:"environment,omitempty"`
+	Message     string            `json:"message"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	User        map[string]string `json:"user,omitempty"`
+	Request     map[string]string `json:"request,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+func (s *SentrySink) Send(ctx context.Context, event Event) error {
+	payload := sentryEvent{
+		EventID:     event.ID,
+		Timestamp:   event.Time.Format(time.RFC3339),
+		Level:       event.Level,
+		Platform:    "go",
+		Environment: event.Environment,
+		Message:     event.Message,
+		Tags: map[string]string{
+			"route":  event.Route,
+			"status": fmt.Sprint(event.Status),
+		},
+		Request: map[string]string{"method": event.Method, "url": event.Path},
+		Extra:   map[string]string{"requestId": event.RequestID, "stack": event.Stack},
+	}
+	if event.UserID != "" {
+		payload.User = map[string]string{"id": event.UserID}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("sentry responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *SentrySink) Close() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fiber-go-template/helper/pagination"
+)
+
+// Fieldset is the validated list of JSON fields requested with ?fields=.
+// An empty Fieldset means every exposed field.
+type Fieldset []string
+
+// Error is returned when a requested field is not exposed by the model.
+type Error struct {
+	Field string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("field '%s' is not available", e.Field)
+}
+
+// Parse splits a comma separated fields parameter and validates it against the exposed fields.
+func Parse(raw string, exposed map[string]string) (fields Fieldset, err error) {
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || fields.Has(field) {
+			continue
+		}
+
+		if _, ok := exposed[field]; !ok {
+			return nil, &Error{Field: field}
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Has reports whether the field was requested.
+func (f Fieldset) Has(field string) bool {
+	for _, item := range f {
+		if item == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the requested fields sorted and comma separated, the same for any order.
+func (f Fieldset) Key() string {
+	fields := append([]string(nil), f...)
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
+
+// Columns maps the requested fields to database columns, always including the required columns.
+// It returns nil when every column should be selected.
+func (f Fieldset) Columns(exposed map[string]string, required ...string) []string {
+	if len(f) == 0 {
+		return nil
+	}
+
+	columns := append([]string{}, required...)
+	for _, field := range f {
+		column := exposed[field]
+		if !contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// Item is the projected JSON object of a model.
+type Item map[string]json.RawMessage
+
+// Project narrows the JSON representation of a model down to the requested fields.
+func (f Fieldset) Project(data interface{}) (Item, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var all Item
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+
+	projected := make(Item, len(f))
+	for _, field := range f {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+
+	return projected, nil
+}
+
+// ProjectAll narrows every item of a slice.
+func ProjectAll[T any](f Fieldset, items []T) ([]Item, error) {
+	projected := make([]Item, 0, len(items))
+	for _, item := range items {
+		value, err := f.Project(item)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, value)
+	}
+
+	return projected, nil
+}
+
+// ProjectPage narrows every item of a page, keeping its metadata.
+func ProjectPage[T any](f Fieldset, page pagination.Response[T]) (pagination.Response[Item], error) {
+	items, err := ProjectAll(f, page.Items)
+	if err != nil {
+		return pagination.Response[Item]{}, err
+	}
+
+	return pagination.Response[Item]{Items: items, Meta: page.Meta, Cursor: page.Cursor}, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, value := range items {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
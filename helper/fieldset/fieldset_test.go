@@ -0,0 +1,129 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exposed = map[string]string{"id": "id", "name": "name", "createdAt": "created_at"}
+
+type author struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Password  string `json:"password"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Fieldset
+		wantErr string
+	}{
+		{"empty", "", nil, ""},
+		{"fields", "name,id", Fieldset{"name", "id"}, ""},
+		{"spaces and duplicates", " name , ,name,createdAt", Fieldset{"name", "createdAt"}, ""},
+		{"not exposed", "name,password", nil, "field 'password' is not available"},
+		{"column name", "created_at", nil, "field 'created_at' is not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := Parse(tt.raw, exposed)
+			if tt.wantErr != "" {
+				var fieldErr *Error
+				require.ErrorAs(t, err, &fieldErr)
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   Fieldset
+		required []string
+		want     []string
+	}{
+		{"every column", nil, []string{"id"}, nil},
+		{"required first", Fieldset{"name", "createdAt"}, []string{"id"}, []string{"id", "name", "created_at"}},
+		{"required not repeated", Fieldset{"id", "name"}, []string{"id"}, []string{"id", "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fields.Columns(exposed, tt.required...))
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	item := author{ID: "1", Name: "Pramoedya", Password: "hash", CreatedAt: "2024-01-01"}
+
+	tests := []struct {
+		name   string
+		fields Fieldset
+		want   string
+	}{
+		{"one field", Fieldset{"name"}, `{"name":"Pramoedya"}`},
+		{"fields", Fieldset{"id", "createdAt"}, `{"createdAt":"2024-01-01","id":"1"}`},
+		{"missing field", Fieldset{"address"}, `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected, err := tt.fields.Project(item)
+			require.NoError(t, err)
+			raw, err := json.Marshal(projected)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(raw))
+		})
+	}
+}
+
+func TestProjectPage(t *testing.T) {
+	page := pagination.Response[author]{
+		Items: []author{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}},
+		Meta:  pagination.CreateMeta(2, 10, 1),
+	}
+
+	projected, err := ProjectPage(Fieldset{"name"}, page)
+	require.NoError(t, err)
+	assert.Same(t, page.Meta, projected.Meta)
+	raw, err := json.Marshal(projected.Items)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"a"},{"name":"b"}]`, string(raw))
+
+	empty, err := ProjectAll[author](Fieldset{"name"}, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, empty, "an empty page is encoded as []")
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields Fieldset
+		want   string
+	}{
+		{"every field", nil, ""},
+		{"one field", Fieldset{"name"}, "name"},
+		{"sorted", Fieldset{"name", "id", "address"}, "address,id,name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := append(Fieldset(nil), tt.fields...)
+			assert.Equal(t, tt.want, tt.fields.Key())
+			assert.Equal(t, fields, tt.fields, "the fieldset is not modified")
+		})
+	}
+}
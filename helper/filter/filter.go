@@ -0,0 +1,336 @@
+package filter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiber-go-template/database/dialect"
+	"github.com/fiber-go-template/helper/format"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// Operators supported by the filter query string, e.g. filter[name][contains]=foo.
+const (
+	OpEq         = "eq"
+	OpNe         = "ne"
+	OpGt         = "gt"
+	OpGte        = "gte"
+	OpLt         = "lt"
+	OpLte        = "lte"
+	OpContains   = "contains"
+	OpStartsWith = "startsWith"
+	OpEndsWith   = "endsWith"
+	OpIn         = "in"
+	OpIsNull     = "isNull"
+)
+
+var (
+	// StringOperators is the common operator set for text columns.
+	StringOperators = []string{OpEq, OpNe, OpContains, OpStartsWith, OpEndsWith, OpIn, OpIsNull}
+
+	// ComparableOperators is the common operator set for number and date columns.
+	ComparableOperators = []string{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn, OpIsNull}
+
+	// EqualityOperators is the common operator set for boolean and identifier columns.
+	EqualityOperators = []string{OpEq, OpNe, OpIn, OpIsNull}
+)
+
+// filterKey matches filter[field] and filter[field][operator].
+var filterKey = regexp.MustCompile(`^filter\[([A-Za-z0-9_]+)\](?:\[([A-Za-z]+)\])?$`)
+
+// Type describes how raw query values are converted before reaching the database.
+type Type int
+
+const (
+	TypeString Type = iota
+	TypeNumber
+	TypeBool
+	TypeTime
+	TypeUUID
+)
+
+// Field describes one filterable column of a model.
+type Field struct {
+	Column    string
+	Type      Type
+	Operators []string
+}
+
+// Fields is a whitelist of filterable fields keyed by their query string name.
+type Fields map[string]Field
+
+// Condition is a single validated filter expression.
+type Condition struct {
+	Field    string
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// Error is returned for unknown fields, operators or malformed values.
+// Message is the English message, used as the i18n catalog key, formatted with Args.
+type Error struct {
+	Field   string
+	Message string
+	Args    []interface{}
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if len(e.Args) > 0 {
+		message = fmt.Sprintf(e.Message, e.Args...)
+	}
+
+	if e.Field == "" {
+		return message
+	}
+	return fmt.Sprintf("invalid filter '%s': %s", e.Field, message)
+}
+
+// invalid returns the error of a field, the Message and Args of err when it is an Error.
+func invalid(field string, err error) *Error {
+	filterErr, ok := err.(*Error)
+	if !ok {
+		return &Error{Field: field, Message: err.Error()}
+	}
+	return &Error{Field: field, Message: filterErr.Message, Args: filterErr.Args}
+}
+
+// Parse reads every filter[...] key from the query string and validates it against the whitelist.
+func Parse(queries map[string]string, fields Fields) (conditions []Condition, err error) {
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		keys = append(keys, key)
+	}
+	// Keep the generated SQL stable between identical requests.
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		match := filterKey.FindStringSubmatch(key)
+		if match == nil {
+			return nil, &Error{Field: key, Message: "malformed filter key"}
+		}
+
+		operator := match[2]
+		if operator == "" {
+			operator = OpEq
+		}
+
+		condition, err := NewCondition(fields, match[1], operator, queries[key])
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+// DateRange builds the conditions for the standard startDate and endDate query parameters.
+func DateRange(fields Fields, field, startDate, endDate string) (conditions []Condition, err error) {
+	if startDate != "" {
+		condition, err := NewCondition(fields, field, OpGte, startDate)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if endDate != "" {
+		operator := OpLte
+		// A plain date includes the whole day.
+		if day, err := time.Parse(format.DefaultDateFormat, endDate); err == nil {
+			operator = OpLt
+			endDate = day.AddDate(0, 0, 1).Format(format.DefaultDateFormat)
+		}
+
+		condition, err := NewCondition(fields, field, operator, endDate)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+// NewCondition validates a single field and operator pair and converts its raw value.
+func NewCondition(fields Fields, field, operator, raw string) (condition Condition, err error) {
+	definition, ok := fields[field]
+	if !ok {
+		return condition, &Error{Field: field, Message: "field is not filterable"}
+	}
+
+	if !definition.allows(operator) {
+		return condition, &Error{Field: field, Message: "operator '%s' is not supported", Args: []interface{}{operator}}
+	}
+
+	var value interface{}
+	switch operator {
+	case OpIsNull:
+		value, err = Field{Type: TypeBool}.Convert(raw)
+	case OpIn:
+		var values []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			converted, err := definition.Convert(strings.TrimSpace(item))
+			if err != nil {
+				return condition, invalid(field, err)
+			}
+			values = append(values, converted)
+		}
+		value = values
+	case OpContains, OpStartsWith, OpEndsWith:
+		value = escapeLike(raw)
+	default:
+		value, err = definition.Convert(raw)
+	}
+	if err != nil {
+		return condition, invalid(field, err)
+	}
+
+	return Condition{
+		Field:    field,
+		Column:   definition.Column,
+		Operator: operator,
+		Value:    value,
+	}, nil
+}
+
+// SQL returns the condition as a parameterized SQL fragment of d using ? placeholders.
+func (c Condition) SQL(d dialect.Dialect) (string, []interface{}) {
+	switch c.Operator {
+	case OpNe:
+		return c.Column + " <> ?", []interface{}{c.Value}
+	case OpGt:
+		return c.Column + " > ?", []interface{}{c.Value}
+	case OpGte:
+		return c.Column + " >= ?", []interface{}{c.Value}
+	case OpLt:
+		return c.Column + " < ?", []interface{}{c.Value}
+	case OpLte:
+		return c.Column + " <= ?", []interface{}{c.Value}
+	case OpContains:
+		return d.ILike(c.Column), []interface{}{"%" + c.Value.(string) + "%"}
+	case OpStartsWith:
+		return d.ILike(c.Column), []interface{}{c.Value.(string) + "%"}
+	case OpEndsWith:
+		return d.ILike(c.Column), []interface{}{"%" + c.Value.(string)}
+	case OpIn:
+		values := c.Value.([]interface{})
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		return c.Column + " IN (" + placeholders + ")", values
+	case OpIsNull:
+		if c.Value.(bool) {
+			return c.Column + " IS NULL", nil
+		}
+		return c.Column + " IS NOT NULL", nil
+	default:
+		return c.Column + " = ?", []interface{}{c.Value}
+	}
+}
+
+// Build joins the conditions into a fragment ready to append after an existing WHERE clause.
+// The placeholders must be rebound with d.Rebind before execution.
+func Build(d dialect.Dialect, conditions []Condition) (string, []interface{}) {
+	var query strings.Builder
+	var params []interface{}
+	for _, condition := range conditions {
+		clause, args := condition.SQL(d)
+		query.WriteString(" AND " + clause + " ")
+		params = append(params, args...)
+	}
+
+	return query.String(), params
+}
+
+// Key func for a canonical key of conditions, e.g. to cache the results of a list.
+// It is the same for any order of the conditions.
+func Key(conditions []Condition) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(conditions))
+	for i, condition := range conditions {
+		value, err := json.Marshal(condition.Value)
+		if err != nil {
+			value = []byte(fmt.Sprintf("%#v", condition.Value))
+		}
+		parts[i] = condition.Field + "\x00" + condition.Operator + "\x00" + string(value)
+	}
+	sort.Strings(parts)
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Scope applies the conditions to a GORM query, e.g. db.Scopes(filter.Scope(d, conditions)).
+func Scope(d dialect.Dialect, conditions []Condition) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, condition := range conditions {
+			clause, args := condition.SQL(d)
+			db = db.Where(clause, args...)
+		}
+		return db
+	}
+}
+
+func (f Field) allows(operator string) bool {
+	for _, allowed := range f.Operators {
+		if allowed == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert parses raw into the Go value of the field type, e.g. time.Time for TypeTime.
+// A malformed raw returns an Error without Field.
+func (f Field) Convert(raw string) (interface{}, error) {
+	switch f.Type {
+	case TypeNumber:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, &Error{Message: "'%s' is not a number", Args: []interface{}{raw}}
+		}
+		return value, nil
+	case TypeBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, &Error{Message: "'%s' is not a boolean", Args: []interface{}{raw}}
+		}
+		return value, nil
+	case TypeTime:
+		for _, layout := range []string{time.RFC3339, format.DefaultDateTimeFormat, format.DefaultDateFormat} {
+			if value, err := time.Parse(layout, raw); err == nil {
+				return value, nil
+			}
+		}
+		return nil, &Error{Message: "'%s' is not a valid date", Args: []interface{}{raw}}
+	case TypeUUID:
+		value, err := uuid.FromString(raw)
+		if err != nil {
+			return nil, &Error{Message: "'%s' is not a valid UUID", Args: []interface{}{raw}}
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// escapeLike makes LIKE wildcards in user input match literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
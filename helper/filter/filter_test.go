@@ -0,0 +1,201 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/database/dialect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fields = Fields{
+	"id":        {Column: "id", Type: TypeUUID, Operators: EqualityOperators},
+	"name":      {Column: "name", Type: TypeString, Operators: StringOperators},
+	"age":       {Column: "age", Type: TypeNumber, Operators: ComparableOperators},
+	"active":    {Column: "is_active", Type: TypeBool, Operators: EqualityOperators},
+	"createdAt": {Column: "created_at", Type: TypeTime, Operators: ComparableOperators},
+}
+
+func TestParse(t *testing.T) {
+	id, _ := uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	tests := []struct {
+		name    string
+		queries map[string]string
+		want    []Condition
+		errText string
+	}{
+		{"no filters", map[string]string{"q": "x", "pageSize": "10"}, nil, ""},
+		{"operator defaults to eq", map[string]string{"filter[name]": "Pram"}, []Condition{{Field: "name", Column: "name", Operator: OpEq, Value: "Pram"}}, ""},
+		{"sorted by key", map[string]string{"filter[name][contains]": "a_b", "filter[age][gte]": "18"}, []Condition{
+			{Field: "age", Column: "age", Operator: OpGte, Value: float64(18)},
+			{Field: "name", Column: "name", Operator: OpContains, Value: `a\_b`},
+		}, ""},
+		{"uuid", map[string]string{"filter[id]": id.String()}, []Condition{{Field: "id", Column: "id", Operator: OpEq, Value: id}}, ""},
+		{"bool", map[string]string{"filter[active][ne]": "true"}, []Condition{{Field: "active", Column: "is_active", Operator: OpNe, Value: true}}, ""},
+		{"date", map[string]string{"filter[createdAt][lt]": "2024-01-02"}, []Condition{
+			{Field: "createdAt", Column: "created_at", Operator: OpLt, Value: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		}, ""},
+		{"in", map[string]string{"filter[age][in]": "1, 2"}, []Condition{{Field: "age", Column: "age", Operator: OpIn, Value: []interface{}{float64(1), float64(2)}}}, ""},
+		{"is null", map[string]string{"filter[name][isNull]": "false"}, []Condition{{Field: "name", Column: "name", Operator: OpIsNull, Value: false}}, ""},
+		{"malformed key", map[string]string{"filter[name": "x"}, nil, "invalid filter 'filter[name': malformed filter key"},
+		{"unknown field", map[string]string{"filter[password]": "x"}, nil, "invalid filter 'password': field is not filterable"},
+		{"unsupported operator", map[string]string{"filter[name][gt]": "x"}, nil, "invalid filter 'name': operator 'gt' is not supported"},
+		{"unknown operator", map[string]string{"filter[name][like]": "x"}, nil, "invalid filter 'name': operator 'like' is not supported"},
+		{"not a number", map[string]string{"filter[age]": "old"}, nil, "invalid filter 'age': 'old' is not a number"},
+		{"not a date", map[string]string{"filter[createdAt][gte]": "yesterday"}, nil, "invalid filter 'createdAt': 'yesterday' is not a valid date"},
+		{"not a uuid in a list", map[string]string{"filter[id][in]": id.String() + ",1"}, nil, "invalid filter 'id': '1' is not a valid UUID"},
+		{"is null not a boolean", map[string]string{"filter[name][isNull]": "maybe"}, nil, "invalid filter 'name': 'maybe' is not a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := Parse(tt.queries, fields)
+			if tt.errText != "" {
+				var filterErr *Error
+				require.ErrorAs(t, err, &filterErr)
+				assert.EqualError(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, conditions)
+		})
+	}
+}
+
+// TestErrorMessage checks that the messages are the catalog keys, the values are in Args.
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		op      string
+		raw     string
+		message string
+		args    []interface{}
+	}{
+		{"unknown field", "password", OpEq, "x", "field is not filterable", nil},
+		{"unsupported operator", "name", OpGt, "x", "operator '%s' is not supported", []interface{}{OpGt}},
+		{"not a number", "age", OpEq, "old", "'%s' is not a number", []interface{}{"old"}},
+		{"not a boolean", "active", OpEq, "yes", "'%s' is not a boolean", []interface{}{"yes"}},
+		{"not a date", "createdAt", OpGte, "soon", "'%s' is not a valid date", []interface{}{"soon"}},
+		{"not a uuid in a list", "id", OpIn, "1", "'%s' is not a valid UUID", []interface{}{"1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCondition(fields, tt.field, tt.op, tt.raw)
+			var filterErr *Error
+			require.ErrorAs(t, err, &filterErr)
+			assert.Equal(t, tt.field, filterErr.Field)
+			assert.Equal(t, tt.message, filterErr.Message)
+			assert.Equal(t, tt.args, filterErr.Args)
+		})
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		want       []Condition
+		wantErr    bool
+	}{
+		{"none", "", "", nil, false},
+		{"whole end day", "2024-01-01", "2024-01-31", []Condition{
+			{Field: "createdAt", Column: "created_at", Operator: OpGte, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Field: "createdAt", Column: "created_at", Operator: OpLt, Value: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		}, false},
+		{"end time", "", "2024-01-31T12:00:00Z", []Condition{
+			{Field: "createdAt", Column: "created_at", Operator: OpLte, Value: time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)},
+		}, false},
+		{"invalid", "soon", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := DateRange(fields, "createdAt", tt.start, tt.end)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, conditions)
+		})
+	}
+}
+
+func TestConditionSQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		sql       string
+		args      []interface{}
+	}{
+		{"eq", Condition{Column: "name", Operator: OpEq, Value: "x"}, "name = ?", []interface{}{"x"}},
+		{"ne", Condition{Column: "name", Operator: OpNe, Value: "x"}, "name <> ?", []interface{}{"x"}},
+		{"gt", Condition{Column: "age", Operator: OpGt, Value: 1}, "age > ?", []interface{}{1}},
+		{"gte", Condition{Column: "age", Operator: OpGte, Value: 1}, "age >= ?", []interface{}{1}},
+		{"lt", Condition{Column: "age", Operator: OpLt, Value: 1}, "age < ?", []interface{}{1}},
+		{"lte", Condition{Column: "age", Operator: OpLte, Value: 1}, "age <= ?", []interface{}{1}},
+		{"contains", Condition{Column: "name", Operator: OpContains, Value: "a"}, "name ILIKE ?", []interface{}{"%a%"}},
+		{"starts with", Condition{Column: "name", Operator: OpStartsWith, Value: "a"}, "name ILIKE ?", []interface{}{"a%"}},
+		{"ends with", Condition{Column: "name", Operator: OpEndsWith, Value: "a"}, "name ILIKE ?", []interface{}{"%a"}},
+		{"in", Condition{Column: "age", Operator: OpIn, Value: []interface{}{1, 2, 3}}, "age IN (?, ?, ?)", []interface{}{1, 2, 3}},
+		{"is null", Condition{Column: "name", Operator: OpIsNull, Value: true}, "name IS NULL", nil},
+		{"is not null", Condition{Column: "name", Operator: OpIsNull, Value: false}, "name IS NOT NULL", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.condition.SQL(dialect.Postgres{})
+			assert.Equal(t, tt.sql, sql)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	sql, args := Build(dialect.Postgres{}, []Condition{
+		{Column: "name", Operator: OpStartsWith, Value: "a"},
+		{Column: "age", Operator: OpIn, Value: []interface{}{1, 2}},
+	})
+	assert.Equal(t, " AND name ILIKE ?  AND age IN (?, ?) ", sql)
+	assert.Equal(t, []interface{}{"a%", 1, 2}, args)
+
+	sql, args = Build(dialect.Postgres{}, nil)
+	assert.Empty(t, sql)
+	assert.Empty(t, args)
+}
+
+func TestKey(t *testing.T) {
+	name := Condition{Field: "name", Column: "name", Operator: OpEq, Value: "Pramoedya"}
+	after := Condition{Field: "createdAt", Column: "created_at", Operator: OpGte, Value: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	before := Condition{Field: "createdAt", Column: "created_at", Operator: OpLte, Value: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	in := Condition{Field: "name", Column: "name", Operator: OpIn, Value: []interface{}{"a", "b"}}
+
+	tests := []struct {
+		name  string
+		a, b  []Condition
+		equal bool
+	}{
+		{"no conditions", nil, []Condition{}, true},
+		{"any order", []Condition{name, after, before}, []Condition{before, name, after}, true},
+		{"other value", []Condition{name}, []Condition{{Field: "name", Column: "name", Operator: OpEq, Value: "Chairil"}}, false},
+		{"other operator", []Condition{after}, []Condition{{Field: "createdAt", Column: "created_at", Operator: OpGt, Value: after.Value}}, false},
+		{"value of another type", []Condition{{Field: "name", Operator: OpEq, Value: "1"}}, []Condition{{Field: "name", Operator: OpEq, Value: 1}}, false},
+		{"list values", []Condition{in}, []Condition{{Field: "name", Column: "name", Operator: OpIn, Value: []interface{}{"b", "a"}}}, false},
+		{"subset", []Condition{name, after}, []Condition{name}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.equal {
+				assert.Equal(t, Key(tt.a), Key(tt.b))
+			} else {
+				assert.NotEqual(t, Key(tt.a), Key(tt.b))
+			}
+		})
+	}
+}
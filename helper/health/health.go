@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status values of a report and of each check.
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// Check pings a dependency, returning an error when it is not usable.
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the readiness of the server and of each dependency.
+type Report struct {
+	Status       string                 `json:"status"`
+	ShuttingDown bool                   `json:"shuttingDown,omitempty"`
+	Checks       map[string]CheckResult `json:"checks"`
+}
+
+// shuttingDown is set once the graceful shutdown has started.
+var shuttingDown atomic.Bool
+
+// SetShuttingDown marks the server as not ready, so new traffic goes elsewhere.
+func SetShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// ShuttingDown reports whether the graceful shutdown has started.
+func ShuttingDown() bool {
+	return shuttingDown.Load()
+}
+
+// Checker runs the registered checks concurrently, each bounded by a timeout.
+type Checker struct {
+	Timeout time.Duration
+	checks  map[string]Check
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		Timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register adds a named check.
+func (h *Checker) Register(name string, check Check) {
+	h.checks[name] = check
+}
+
+// Ready runs every check and reports ready when all are up and the server is not shutting down.
+func (h *Checker) Ready(ctx context.Context) Report {
+	report := Report{
+		Status:       StatusReady,
+		ShuttingDown: ShuttingDown(),
+		Checks:       make(map[string]CheckResult, len(h.checks)),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			result := h.run(ctx, check)
+
+			mu.Lock()
+			report.Checks[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusNotReady
+		}
+	}
+	if report.ShuttingDown {
+		report.Status = StatusNotReady
+	}
+
+	return report
+}
+
+func (h *Checker) run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package i18n
+
+// catalog holds the translations of user-facing messages, keyed by the English message.
+// English needs no entries.
+var catalog = map[string]map[string]string{
+	ID: {
+		// HTTP status titles
+		"Bad Request":              "Permintaan Tidak Valid",
+		"Unauthorized":             "Tidak Terautentikasi",
+		"Forbidden":                "Akses Ditolak",
+		"Not Found":                "Tidak Ditemukan",
+		"Method Not Allowed":       "Metode Tidak Diizinkan",
+		"Conflict":                 "Konflik",
+		"Request Entity Too Large": "Permintaan Terlalu Besar",
+		"Unprocessable Entity":     "Data Tidak Dapat Diproses",
+		"Too Many Requests":        "Terlalu Banyak Permintaan",
+		"Internal Server Error":    "Kesalahan Server Internal",
+		"Service Unavailable":      "Layanan Tidak Tersedia",
+
+		// Errors
+		"internal server error":                             "terjadi kesalahan pada server",
+		"sorry, endpoint is not found":                      "maaf, endpoint tidak ditemukan",
+		"data with the given ID is not found":               "data dengan ID tersebut tidak ditemukan",
+		"data already exists":                               "data sudah ada",
+		"request validation failed":                         "validasi permintaan gagal",
+		"invalid path parameter":                            "parameter path tidak valid",
+		"invalid path parameter: %s":                        "parameter path tidak valid: %s",
+		"invalid query parameter: %s":                       "parameter query tidak valid: %s",
+		"invalid request body: %s":                          "isi permintaan tidak valid: %s",
+		"must be a valid UUID":                              "harus berupa UUID yang valid",
+		"invalid fields parameter":                          "parameter fields tidak valid",
+		"field is not available":                            "field tidak tersedia",
+		"invalid filter":                                    "filter tidak valid",
+		"malformed filter key":                              "format kunci filter salah",
+		"field is not filterable":                           "field tidak dapat difilter",
+		"operator '%s' is not supported":                    "operator '%s' tidak didukung",
+		"'%s' is not a number":                              "'%s' bukan angka",
+		"'%s' is not a boolean":                             "'%s' bukan boolean",
+		"'%s' is not a valid date":                          "'%s' bukan tanggal yang valid",
+		"'%s' is not a valid UUID":                          "'%s' bukan UUID yang valid",
+		"invalid sortBy parameter":                          "parameter sortBy tidak valid",
+		"invalid pagination cursor":                         "cursor paginasi tidak valid",
+		"missing or malformed JWT":                          "JWT tidak ada atau formatnya salah",
+		"invalid or expired JWT":                            "JWT tidak valid atau sudah kedaluwarsa",
+		"unauthorized, check expiration time of your token": "tidak terautentikasi, periksa masa berlaku token Anda",
+		"unauthorized, your session was ended earlier":      "tidak terautentikasi, sesi Anda telah berakhir",
+		"wrong user username address or password":           "username atau password salah",
+		"you are not allowed to access this resource":       "Anda tidak diizinkan mengakses resource ini",
+		"too many requests, please try again later":         "terlalu banyak permintaan, silakan coba lagi nanti",
+		"invalid %s header":                                 "header %s tidak valid",
+		"%s was already used with a different request":      "%s sudah digunakan untuk permintaan yang berbeda",
+		"a request with the same %s is still in progress":   "permintaan dengan %s yang sama masih diproses",
+		"invalid CSRF token":                                "token CSRF tidak valid",
+		"invalid configuration":                             "konfigurasi tidak valid",
+		"invalid refresh token":                             "refresh token tidak valid",
+
+		// Success messages
+		"Create data successfully":            "Data berhasil dibuat",
+		"Update data successfully":            "Data berhasil diperbarui",
+		"Delete data successfully":            "Data berhasil dihapus",
+		"Log level changed successfully":      "Level log berhasil diubah",
+		"Configuration reloaded successfully": "Konfigurasi berhasil dimuat ulang",
+	},
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Supported locales.
+const (
+	EN = "en"
+	ID = "id"
+)
+
+// localsKey is the fiber.Ctx locals key holding the resolved locale.
+const localsKey = "locale"
+
+// PreferenceKey is the query parameter and cookie name a user picks the locale with.
+const PreferenceKey = "lang"
+
+// Supported lists the locales having a catalog.
+var Supported = []string{EN, ID}
+
+// defaultLocale is the locale set with SetDefault.
+var defaultLocale = EN
+
+// SetDefault sets the locale used when the request does not ask for a supported one,
+// it is set once at startup.
+func SetDefault(locale string) {
+	if locale = normalize(locale); locale != "" {
+		defaultLocale = locale
+	}
+}
+
+// Default returns the locale used when the request does not ask for a supported one.
+func Default() string {
+	return defaultLocale
+}
+
+// New creates the middleware resolving the locale of each request.
+// See Resolve for the lookup order.
+func New() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		locale := Resolve(c)
+		c.Locals(localsKey, locale)
+		c.Set(fiber.HeaderContentLanguage, locale)
+
+		return c.Next()
+	}
+}
+
+// Resolve picks the locale from the lang query parameter, the lang cookie,
+// then the Accept-Language header, falling back to Default.
+func Resolve(c *fiber.Ctx) string {
+	if locale := normalize(c.Query(PreferenceKey)); locale != "" {
+		return locale
+	}
+	if locale := normalize(c.Cookies(PreferenceKey)); locale != "" {
+		return locale
+	}
+	if c.Get(fiber.HeaderAcceptLanguage) != "" {
+		if locale := c.AcceptsLanguages(Supported...); locale != "" {
+			return locale
+		}
+	}
+
+	return Default()
+}
+
+// Locale returns the locale of the request, resolving it when the middleware did not run.
+func Locale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals(localsKey).(string); ok {
+		return locale
+	}
+	return Resolve(c)
+}
+
+// Translate returns the message in the given locale, formatted with args when given.
+// Messages are keyed by their English text, so unknown messages are returned as is.
+func Translate(locale, message string, args ...interface{}) string {
+	if translated, ok := catalog[locale][message]; ok {
+		message = translated
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// T translates the message in the locale of the request.
+func T(c *fiber.Ctx, message string, args ...interface{}) string {
+	return Translate(Locale(c), message, args...)
+}
+
+// normalize returns the supported locale matching a tag like id-ID, or "".
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	for _, locale := range Supported {
+		if tag == locale {
+			return locale
+		}
+	}
+	return ""
+}
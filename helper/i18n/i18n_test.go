@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		message string
+		args    []interface{}
+		want    string
+	}{
+		{"english is the key", EN, "data already exists", nil, "data already exists"},
+		{"indonesian", ID, "data already exists", nil, "data sudah ada"},
+		{"formatted after translation", ID, "invalid query parameter: %s", []interface{}{"pageSize"}, "parameter query tidak valid: pageSize"},
+		{"formatted english", EN, "invalid query parameter: %s", []interface{}{"pageSize"}, "invalid query parameter: pageSize"},
+		{"unknown message", ID, "something new", nil, "something new"},
+		{"unknown locale", "fr", "data already exists", nil, "data already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Translate(tt.locale, tt.message, tt.args...))
+		})
+	}
+}
+
+// TestCatalogFormats checks that every translation keeps the verbs of its English message.
+func TestCatalogFormats(t *testing.T) {
+	for locale, messages := range catalog {
+		for message, translated := range messages {
+			assert.Equal(t, countVerbs(message), countVerbs(translated), "%s: %q", locale, message)
+		}
+	}
+}
+
+func countVerbs(message string) int {
+	count := 0
+	for i := 0; i < len(message)-1; i++ {
+		if message[i] == '%' {
+			if message[i+1] != '%' {
+				count++
+			}
+			i++
+		}
+	}
+	return count
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		cookie   string
+		accept   string
+		fallback string
+		want     string
+	}{
+		{"default", "", "", "", EN, EN},
+		{"configured default", "", "", "", ID, ID},
+		{"query", "?lang=id", "", "", EN, ID},
+		{"query region", "?lang=id-ID", "", "", EN, ID},
+		{"query wins over cookie", "?lang=en", "id", "", ID, EN},
+		{"cookie", "", "id", "en", EN, ID},
+		{"accept language", "", "", "fr;q=1, id;q=0.8", EN, ID},
+		{"unsupported query", "?lang=fr", "", "id", EN, ID},
+		{"unsupported everywhere", "?lang=fr", "de", "fr", ID, ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := Default()
+			SetDefault(tt.fallback)
+			t.Cleanup(func() { SetDefault(previous) })
+
+			app := fiber.New()
+			app.Use(New())
+			app.Get("/", func(c *fiber.Ctx) error { return c.SendString(Locale(c)) })
+
+			req := httptest.NewRequest(fiber.MethodGet, "/"+tt.query, nil)
+			if tt.cookie != "" {
+				req.Header.Set(fiber.HeaderCookie, PreferenceKey+"="+tt.cookie)
+			}
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.accept)
+			}
+			res, err := app.Test(req, -1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res.Header.Get(fiber.HeaderContentLanguage))
+		})
+	}
+}
+
+func TestSetDefaultIgnoresUnsupported(t *testing.T) {
+	previous := Default()
+	t.Cleanup(func() { SetDefault(previous) })
+
+	SetDefault(ID)
+	SetDefault("fr")
+	assert.Equal(t, ID, Default())
+}
@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/fiber-go-template/database"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+)
+
+// DBStore keeps the records in the idempotency_keys table.
+type DBStore struct {
+	DB database.DBConn
+}
+
+// row is a record of the idempotency_keys table.
+type row struct {
+	Key         string    `gorm:"column:idempotency_key;primaryKey"`
+	Fingerprint string    `gorm:"column:fingerprint"`
+	Completed   bool      `gorm:"column:completed"`
+	Status      int       `gorm:"column:status"`
+	ContentType string    `gorm:"column:content_type"`
+	Body        []byte    `gorm:"column:body"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+}
+
+func (row) TableName() string {
+	return "idempotency_keys"
+}
+
+// NewDBStore creates a DBStore deleting the expired records every cleanupInterval.
+func NewDBStore(db database.DBConn, cleanupInterval time.Duration) *DBStore {
+	store := &DBStore{DB: db}
+	go store.cleanup(cleanupInterval)
+	return store
+}
+
+func (s *DBStore) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, error) {
+	now := time.Now()
+	record := Record{Fingerprint: fingerprint}
+
+	// The primary key makes the insert the lock, the first request wins.
+	result := s.DB.Orm().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&row{Key: key, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(ttl)})
+	if result.Error != nil || result.RowsAffected == 1 {
+		return record, result.Error
+	}
+
+	var existing row
+	if err := s.DB.Orm().WithContext(ctx).First(&existing, "idempotency_key = ?", key).Error; err != nil {
+		return record, err
+	}
+
+	if existing.ExpiresAt.Before(now) {
+		err := s.DB.Orm().WithContext(ctx).Where("idempotency_key = ? AND expires_at < ?", key, now).Delete(&row{}).Error
+		if err != nil {
+			return record, err
+		}
+		return s.Lock(ctx, key, fingerprint, ttl)
+	}
+
+	return Record{
+		Fingerprint: existing.Fingerprint,
+		Completed:   existing.Completed,
+		Status:      existing.Status,
+		ContentType: existing.ContentType,
+		Body:        existing.Body,
+	}, ErrLocked
+}
+
+func (s *DBStore) Save(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	return s.DB.Orm().WithContext(ctx).Model(&row{}).Where("idempotency_key = ?", key).Updates(map[string]interface{}{
+		"completed":    true,
+		"status":       record.Status,
+		"content_type": record.ContentType,
+		"body":         record.Body,
+		"expires_at":   time.Now().Add(ttl),
+	}).Error
+}
+
+func (s *DBStore) Unlock(ctx context.Context, key string) error {
+	return s.DB.Orm().WithContext(ctx).Where("idempotency_key = ?", key).Delete(&row{}).Error
+}
+
+func (s *DBStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if err := s.DB.Orm().Where("expires_at < ?", now).Delete(&row{}).Error; err != nil {
+			log.Warn().Err(err).Msg("Failed to delete expired idempotency keys.")
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fiber-go-template/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDBStore(t *testing.T) *DBStore {
+	t.Helper()
+
+	conn, err := database.NewMemoryConnection()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Query().Close() })
+
+	return NewDBStore(conn, time.Hour)
+}
+
+func TestDBStoreLock(t *testing.T) {
+	store := newTestDBStore(t)
+	ctx := context.Background()
+
+	_, err := store.Lock(ctx, "k", "f1", time.Minute)
+	require.NoError(t, err)
+
+	record, err := store.Lock(ctx, "k", "f2", time.Minute)
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.Equal(t, Record{Fingerprint: "f1"}, record)
+
+	require.NoError(t, store.Unlock(ctx, "k"))
+	_, err = store.Lock(ctx, "k", "f2", time.Minute)
+	assert.NoError(t, err, "an unlocked key is free")
+}
+
+func TestDBStoreLockExpires(t *testing.T) {
+	store := newTestDBStore(t)
+	ctx := context.Background()
+
+	_, err := store.Lock(ctx, "k", "f", 10*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	_, err = store.Lock(ctx, "k", "f", time.Minute)
+	assert.NoError(t, err, "an expired in-flight key is free")
+}
+
+func TestDBStoreSaveExtendsLock(t *testing.T) {
+	store := newTestDBStore(t)
+	ctx := context.Background()
+
+	_, err := store.Lock(ctx, "k", "f", 10*time.Millisecond)
+	require.NoError(t, err)
+	saved := Record{Fingerprint: "f", Completed: true, Status: 201, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+	require.NoError(t, store.Save(ctx, "k", saved, time.Hour))
+	time.Sleep(20 * time.Millisecond)
+
+	record, err := store.Lock(ctx, "k", "f", time.Minute)
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.Equal(t, saved, record)
+}
+
+func TestKey(t *testing.T) {
+	key := Key("user", "POST /authors", "abc")
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, Key("user", "POST /authors", "abc"))
+
+	for _, other := range []string{Key("other", "POST /authors", "abc"), Key("user", "POST /books", "abc"), Key("user", "POST /authors", "abd")} {
+		assert.NotEqual(t, key, other)
+	}
+}
@@ -0,0 +1,47 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// Header is the request header carrying the idempotency key chosen by the client.
+const Header = "Idempotency-Key"
+
+// ErrLocked is returned by Lock when the key is already used.
+var ErrLocked = errors.New("idempotency key is already used")
+
+// Record is what a Store keeps for a key: the request fingerprint and, once the
+// first request completed, its response.
+type Record struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Store keeps the idempotency records, every method must be safe across instances.
+type Store interface {
+	// Lock reserves the key for a new in-flight request during ttl. When the key is
+	// already used, it returns the existing record with ErrLocked.
+	Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, error)
+	// Save stores the completed response of the request holding the key, kept for ttl.
+	Save(ctx context.Context, key string, record Record, ttl time.Duration) error
+	// Unlock releases the key, so the request can be retried.
+	Unlock(ctx context.Context, key string) error
+}
+
+// Key returns the store key of a client key, scoped to the user and the route.
+func Key(userID, route, clientKey string) string {
+	return Hash([]byte(userID + "\n" + route + "\n" + clientKey))
+}
+
+// Hash returns the hex SHA-256 of data, used for keys and request fingerprints.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
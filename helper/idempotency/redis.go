@@ -0,0 +1,64 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore keeps the records in Redis, expired by Redis itself.
+type RedisStore struct {
+	Client *redis.Client
+	Prefix string
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		Client: client,
+		Prefix: "idempotency:",
+	}
+}
+
+func (s *RedisStore) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, error) {
+	record := Record{Fingerprint: fingerprint}
+	value, err := json.Marshal(record)
+	if err != nil {
+		return record, err
+	}
+
+	locked, err := s.Client.SetNX(ctx, s.Prefix+key, value, ttl).Result()
+	if err != nil || locked {
+		return record, err
+	}
+
+	raw, err := s.Client.Get(ctx, s.Prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// Expired in between, try again.
+		return s.Lock(ctx, key, fingerprint, ttl)
+	}
+	if err != nil {
+		return record, err
+	}
+
+	var existing Record
+	if err := json.Unmarshal(raw, &existing); err != nil {
+		return record, err
+	}
+	return existing, ErrLocked
+}
+
+func (s *RedisStore) Save(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.Client.Set(ctx, s.Prefix+key, value, ttl).Err()
+}
+
+func (s *RedisStore) Unlock(ctx context.Context, key string) error {
+	return s.Client.Del(ctx, s.Prefix+key).Err()
+}
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "app"
+
+// Registry holds every metric exposed on /metrics, Go runtime and process metrics included.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests counts the handled requests by method, route pattern and status.
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests handled, by method, route pattern and status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration observes the request latency by method, route pattern and status.
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, by method, route pattern and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPInFlight is the number of requests being handled.
+	HTTPInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests being handled.",
+	})
+
+	// LoginAttempts counts the sign in attempts by result, success or failure.
+	LoginAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_attempts_total",
+		Help:      "Number of sign in attempts, by result.",
+	}, []string{"result"})
+
+	// CacheRequests counts the cache reads by cache name and result, hit or miss.
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of cache reads, by cache and result.",
+	}, []string{"cache", "result"})
+)
+
+// Login results.
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// Cache results.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+var (
+	dbCollectors   = map[string]prometheus.Collector{}
+	dbCollectorsMu sync.Mutex
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		HTTPInFlight,
+		LoginAttempts,
+		CacheRequests,
+	)
+}
+
+// Handler returns the handler serving the metrics in the Prometheus format.
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware records the count, latency and in-flight number of requests.
+// It must run before the middleware rendering errors, so the status is the one sent.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		HTTPInFlight.Inc()
+		defer HTTPInFlight.Dec()
+
+		err := c.Next()
+
+		status := strconv.Itoa(c.Response().StatusCode())
+		labels := prometheus.Labels{"method": c.Method(), "route": c.Route().Path, "status": status}
+		HTTPRequests.With(labels).Inc()
+		HTTPDuration.With(labels).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// RegisterDB exposes the pool stats of db, labeled with name. Registering the same
+// name again replaces the previous pool, e.g. after a reconnect.
+func RegisterDB(name string, db *sql.DB) {
+	dbCollectorsMu.Lock()
+	defer dbCollectorsMu.Unlock()
+
+	if previous, ok := dbCollectors[name]; ok {
+		Registry.Unregister(previous)
+	}
+
+	collector := collectors.NewDBStatsCollector(db, name)
+	Registry.MustRegister(collector)
+	dbCollectors[name] = collector
+}
@@ -0,0 +1,119 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+const (
+	// ModeOffset paginates with pageNumber and pageSize (LIMIT/OFFSET).
+	ModeOffset = "offset"
+
+	// ModeCursor paginates with opaque after/before cursors (keyset).
+	ModeCursor = "cursor"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded or belongs to another sort order.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor is the decoded content of an after/before cursor.
+type Cursor struct {
+	SortBy string      `json:"s"`
+	Value  interface{} `json:"v"`
+	ID     string      `json:"id"`
+}
+
+// CursorMetadata is the additional info for keyset paginated list data
+type CursorMetadata struct {
+	Next         string `json:"next,omitempty"`
+	Previous     string `json:"previous,omitempty"`
+	HasNext      bool   `json:"hasNext"`
+	HasPrevious  bool   `json:"hasPrevious"`
+	LimitPerPage int    `json:"limitPerPage"`
+	TotalItems   *int   `json:"totalItems,omitempty"`
+}
+
+// EncodeCursor returns the opaque representation of a cursor.
+func EncodeCursor(cursor Cursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses an opaque cursor and checks it was issued for the given sort key.
+func DecodeCursor(raw string, sortBy string) (cursor Cursor, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	if err = json.Unmarshal(decoded, &cursor); err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	if cursor.SortBy != sortBy || cursor.ID == "" || cursor.Value == nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// CreateCursorMeta is a keyset metadata creator. The items must already be in response order.
+func CreateCursorMeta[T any](items []T, sortBy, sortColumn string, pageSize int, hasNext, hasPrevious bool) (meta CursorMetadata) {
+	meta = CursorMetadata{
+		HasNext:      hasNext,
+		HasPrevious:  hasPrevious,
+		LimitPerPage: pageSize,
+	}
+
+	if len(items) == 0 {
+		return meta
+	}
+
+	if hasNext {
+		meta.Next = EncodeCursor(cursorOf(items[len(items)-1], sortBy, sortColumn))
+	}
+
+	if hasPrevious {
+		meta.Previous = EncodeCursor(cursorOf(items[0], sortBy, sortColumn))
+	}
+
+	return meta
+}
+
+func cursorOf(item interface{}, sortBy, sortColumn string) Cursor {
+	return Cursor{
+		SortBy: sortBy,
+		Value:  ColumnValue(item, sortColumn),
+		ID:     toString(ColumnValue(item, "id")),
+	}
+}
+
+// ColumnValue returns the value of the struct field tagged with the given db column.
+func ColumnValue(item interface{}, column string) interface{} {
+	value := reflect.Indirect(reflect.ValueOf(item))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		tag := strings.Split(value.Type().Field(i).Tag.Get("db"), ",")[0]
+		if tag == column {
+			return value.Field(i).Interface()
+		}
+	}
+
+	return nil
+}
+
+func toString(value interface{}) string {
+	if stringer, ok := value.(interface{ String() string }); ok {
+		return stringer.String()
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type row struct {
+	ID        uuid.UUID `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+		want   interface{}
+	}{
+		{"string", Cursor{SortBy: "name", Value: "Pramoedya", ID: "1"}, "Pramoedya"},
+		{"number is decoded as float64", Cursor{SortBy: "age", Value: 42, ID: "1"}, float64(42)},
+		{"time is decoded as its RFC 3339 text", Cursor{SortBy: "createdAt", Value: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), ID: "1"}, "2024-01-02T03:04:05.000000006Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := EncodeCursor(tt.cursor)
+			assert.NotContains(t, raw, "=", "the cursor is unpadded")
+
+			decoded, err := DecodeCursor(raw, tt.cursor.SortBy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.cursor.SortBy, decoded.SortBy)
+			assert.Equal(t, tt.cursor.ID, decoded.ID)
+			assert.Equal(t, tt.want, decoded.Value)
+		})
+	}
+}
+
+func TestDecodeCursorErrors(t *testing.T) {
+	encode := func(json string) string { return base64.RawURLEncoding.EncodeToString([]byte(json)) }
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"not base64", "%%%"},
+		{"not json", encode("cursor")},
+		{"other sort", EncodeCursor(Cursor{SortBy: "name", Value: "x", ID: "1"})},
+		{"missing id", encode(`{"s":"createdAt","v":"x"}`)},
+		{"missing value", encode(`{"s":"createdAt","id":"1"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.raw, "createdAt")
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestCreateCursorMeta(t *testing.T) {
+	first, _ := uuid.NewV4()
+	last, _ := uuid.NewV4()
+	items := []row{{ID: first, Name: "a"}, {ID: last, Name: "b"}}
+
+	tests := []struct {
+		name                 string
+		items                []row
+		hasNext, hasPrevious bool
+		next, previous       *Cursor
+	}{
+		{"single page", items, false, false, nil, nil},
+		{"first page", items, true, false, &Cursor{SortBy: "name", Value: "b", ID: last.String()}, nil},
+		{"middle page", items, true, true, &Cursor{SortBy: "name", Value: "b", ID: last.String()}, &Cursor{SortBy: "name", Value: "a", ID: first.String()}},
+		{"empty page", nil, true, true, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := CreateCursorMeta(tt.items, "name", "name", 2, tt.hasNext, tt.hasPrevious)
+			assert.Equal(t, tt.hasNext, meta.HasNext)
+			assert.Equal(t, tt.hasPrevious, meta.HasPrevious)
+			assert.Equal(t, 2, meta.LimitPerPage)
+
+			for _, link := range []struct {
+				raw  string
+				want *Cursor
+			}{{meta.Next, tt.next}, {meta.Previous, tt.previous}} {
+				if link.want == nil {
+					assert.Empty(t, link.raw)
+					continue
+				}
+				decoded, err := DecodeCursor(link.raw, "name")
+				require.NoError(t, err)
+				assert.Equal(t, *link.want, decoded)
+			}
+		})
+	}
+}
+
+func TestColumnValue(t *testing.T) {
+	id, _ := uuid.NewV4()
+	item := row{ID: id, Name: "a"}
+
+	assert.Equal(t, id, ColumnValue(item, "id"))
+	assert.Equal(t, "a", ColumnValue(&item, "name"))
+	assert.Nil(t, ColumnValue(item, "missing"))
+	assert.Nil(t, ColumnValue("not a struct", "id"))
+}
+
+func TestCreateMeta(t *testing.T) {
+	tests := []struct {
+		name                      string
+		total, size, page         int
+		totalPage, previous, next int
+	}{
+		{"empty", 0, 10, 1, 1, 1, 1},
+		{"first page", 25, 10, 1, 3, 1, 2},
+		{"middle page", 25, 10, 2, 3, 1, 3},
+		{"last page", 25, 10, 3, 3, 2, 3},
+		{"exact pages", 20, 10, 2, 2, 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, &Metadata{
+				TotalItems:   tt.total,
+				TotalPage:    tt.totalPage,
+				PreviousPage: tt.previous,
+				CurrentPage:  tt.page,
+				NextPage:     tt.next,
+				LimitPerPage: tt.size,
+			}, CreateMeta(tt.total, tt.size, tt.page))
+		})
+	}
+}
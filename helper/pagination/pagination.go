@@ -1,13 +1,18 @@
 package pagination
 
 import (
+	"errors"
 	"math"
 )
 
-// Response is a standard list data
-type Response struct {
-	Items []interface{} `json:"items"`
-	Meta  Metadata      `json:"meta"`
+// ErrInvalidSort is returned when sortBy is not one of the sortable fields.
+var ErrInvalidSort = errors.New("invalid sortBy parameter")
+
+// Response is a standard list data, with Meta for offset mode or Cursor for cursor mode
+type Response[T any] struct {
+	Items  []T             `json:"items"`
+	Meta   *Metadata       `json:"meta,omitempty"`
+	Cursor *CursorMetadata `json:"cursor,omitempty"`
 }
 
 // Metadata is a additional info for list data
@@ -21,7 +26,7 @@ type Metadata struct {
 }
 
 // CreateMeta is a metadata creator
-func CreateMeta(totalItems int, dataPerPage int, pageNumber int) (meta Metadata) {
+func CreateMeta(totalItems int, dataPerPage int, pageNumber int) (meta *Metadata) {
 	totalPageRaw := float64(totalItems) / float64(dataPerPage)
 	maxPage := int(math.Ceil(totalPageRaw))
 	minPage := 1
@@ -40,7 +45,7 @@ func CreateMeta(totalItems int, dataPerPage int, pageNumber int) (meta Metadata)
 		prevPage = minPage
 	}
 
-	return Metadata{
+	return &Metadata{
 		TotalItems:   totalItems,
 		TotalPage:    maxPage,
 		PreviousPage: prevPage,
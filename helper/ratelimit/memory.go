@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the counters in process, for single node deployments.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+type memoryEntry struct {
+	// token bucket
+	tokens float64
+	// sliding window
+	start    time.Time
+	previous int
+	current  int
+
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates a MemoryStore removing idle counters every cleanupInterval.
+func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
+	store := &MemoryStore{entries: map[string]*memoryEntry{}}
+	go store.cleanup(cleanupInterval)
+	return store
+}
+
+func (s *MemoryStore) Allow(_ context.Context, key string, policy Policy) (Result, error) {
+	now := time.Now()
+	window := time.Duration(policy.Window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &memoryEntry{tokens: float64(policy.Limit), updatedAt: now, start: windowStart(policy, now)}
+		s.entries[key] = entry
+	}
+	entry.expiresAt = now.Add(2 * window)
+
+	if policy.Algorithm == TokenBucket {
+		tokens := refill(policy, entry.tokens, now.Sub(entry.updatedAt))
+		allowed := tokens >= 1
+		if allowed {
+			tokens--
+		}
+
+		entry.tokens, entry.updatedAt = tokens, now
+		return bucketResult(policy, tokens, allowed), nil
+	}
+
+	// Move the window forward, the previous count is kept only when adjacent.
+	if start := windowStart(policy, now); !start.Equal(entry.start) {
+		if start.Sub(entry.start) == window {
+			entry.previous = entry.current
+		} else {
+			entry.previous = 0
+		}
+		entry.start, entry.current = start, 0
+	}
+
+	elapsed := now.Sub(entry.start)
+	allowed := slidingCount(policy, elapsed, entry.previous, entry.current)+1 <= float64(policy.Limit)
+	if allowed {
+		entry.current++
+	}
+
+	return slidingWindow(policy, elapsed, entry.previous, entry.current, allowed), nil
+}
+
+func (s *MemoryStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.mu.Lock()
+		for key, entry := range s.entries {
+			if now.After(entry.expiresAt) {
+				delete(s.entries, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,206 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Algorithms supported by a Policy.
+const (
+	TokenBucket   = "token_bucket"
+	SlidingWindow = "sliding_window"
+)
+
+// Keys a Policy can limit by.
+const (
+	KeyIP     = "ip"
+	KeyUser   = "user"
+	KeyAPIKey = "api_key"
+)
+
+// Duration is a time.Duration read from a JSON string such as "1m".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(raw []byte) error {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+// Policy limits the requests of a route to Limit per Window for each key.
+type Policy struct {
+	// Route is "METHOD /path" or "/path" for every method. Path segments starting with :
+	// match any segment and a trailing * matches the rest. Empty or * matches every request.
+	Route     string   `json:"route"`
+	Limit     int      `json:"limit"`
+	Window    Duration `json:"window"`
+	Key       string   `json:"key"`
+	Algorithm string   `json:"algorithm"`
+}
+
+// Result is the outcome of a request against a policy.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store counts the requests of each key.
+type Store interface {
+	Allow(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+// ParsePolicies reads a JSON list of policies, filling the default key and algorithm.
+func ParsePolicies(raw string) ([]Policy, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var policies []Policy
+	if err := json.Unmarshal([]byte(raw), &policies); err != nil {
+		return nil, fmt.Errorf("invalid rate limit policies: %w", err)
+	}
+
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Key == "" {
+			policy.Key = KeyIP
+		}
+		if policy.Algorithm == "" {
+			policy.Algorithm = SlidingWindow
+		}
+
+		switch {
+		case policy.Limit <= 0 || policy.Window <= 0:
+			return nil, fmt.Errorf("rate limit policy '%s' needs a positive limit and window", policy.Route)
+		case policy.Key != KeyIP && policy.Key != KeyUser && policy.Key != KeyAPIKey:
+			return nil, fmt.Errorf("rate limit policy '%s' has unknown key '%s'", policy.Route, policy.Key)
+		case policy.Algorithm != TokenBucket && policy.Algorithm != SlidingWindow:
+			return nil, fmt.Errorf("rate limit policy '%s' has unknown algorithm '%s'", policy.Route, policy.Algorithm)
+		}
+	}
+
+	return policies, nil
+}
+
+// Match returns the first policy matching the request, if any.
+func Match(policies []Policy, method, path string) (Policy, bool) {
+	for _, policy := range policies {
+		if matchRoute(policy.Route, method, path) {
+			return policy, true
+		}
+	}
+	return Policy{}, false
+}
+
+func matchRoute(route, method, path string) bool {
+	route = strings.TrimSpace(route)
+	if route == "" || route == "*" {
+		return true
+	}
+
+	if i := strings.IndexByte(route, ' '); i >= 0 {
+		if !strings.EqualFold(route[:i], method) {
+			return false
+		}
+		route = strings.TrimSpace(route[i+1:])
+	}
+
+	patterns := strings.Split(strings.Trim(route, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if !strings.HasPrefix(pattern, ":") && pattern != segments[i] {
+			return false
+		}
+	}
+
+	return len(patterns) == len(segments)
+}
+
+// refill returns the tokens of a bucket refilled continuously for elapsed, the whole
+// limit being refilled over the window.
+func refill(policy Policy, tokens float64, elapsed time.Duration) float64 {
+	perToken := time.Duration(policy.Window) / time.Duration(policy.Limit)
+	return math.Min(float64(policy.Limit), tokens+float64(elapsed)/float64(perToken))
+}
+
+// bucketResult builds the result of a token bucket left with tokens after the request.
+func bucketResult(policy Policy, tokens float64, allowed bool) Result {
+	perToken := time.Duration(policy.Window) / time.Duration(policy.Limit)
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     policy.Limit,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(policy.Limit) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+
+	return result
+}
+
+// slidingCount weights the count of the previous window by the part of it still inside
+// the sliding window.
+func slidingCount(policy Policy, elapsed time.Duration, previous, current int) float64 {
+	window := time.Duration(policy.Window)
+	return float64(previous)*float64(window-elapsed)/float64(window) + float64(current)
+}
+
+// slidingWindow builds the result of a sliding window counter. current already includes
+// the request when allowed.
+func slidingWindow(policy Policy, elapsed time.Duration, previous, current int, allowed bool) Result {
+	window := time.Duration(policy.Window)
+	count := slidingCount(policy, elapsed, previous, current)
+
+	result := Result{
+		Allowed: allowed,
+		Limit:   policy.Limit,
+		Reset:   window - elapsed,
+	}
+	result.Remaining = policy.Limit - int(math.Ceil(count))
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+
+	if !allowed {
+		// Wait until the weighted previous window leaves room for one request.
+		result.RetryAfter = window - elapsed
+		if previous > 0 {
+			room := float64(policy.Limit - current - 1)
+			wait := time.Duration((1-room/float64(previous))*float64(window)) - elapsed
+			if wait > 0 && wait < result.RetryAfter {
+				result.RetryAfter = wait
+			}
+		}
+	}
+
+	return result
+}
+
+// windowStart returns the start of the fixed window holding now.
+func windowStart(policy Policy, now time.Time) time.Time {
+	return now.Truncate(time.Duration(policy.Window))
+}
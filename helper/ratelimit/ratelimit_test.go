@@ -0,0 +1,179 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policy(algorithm string, limit int, window time.Duration) Policy {
+	return Policy{Limit: limit, Window: Duration(window), Key: KeyIP, Algorithm: algorithm}
+}
+
+func TestParsePolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []Policy
+		wantErr bool
+	}{
+		{"empty", " ", nil, false},
+		{"defaults", `[{"route":"/login","limit":5,"window":"1m"}]`, []Policy{
+			{Route: "/login", Limit: 5, Window: Duration(time.Minute), Key: KeyIP, Algorithm: SlidingWindow},
+		}, false},
+		{"explicit", `[{"route":"POST /authors","limit":10,"window":"10s","key":"user","algorithm":"token_bucket"}]`, []Policy{
+			{Route: "POST /authors", Limit: 10, Window: Duration(10 * time.Second), Key: KeyUser, Algorithm: TokenBucket},
+		}, false},
+		{"not json", `{`, nil, true},
+		{"invalid window", `[{"limit":5,"window":"soon"}]`, nil, true},
+		{"no limit", `[{"window":"1m"}]`, nil, true},
+		{"unknown key", `[{"limit":5,"window":"1m","key":"session"}]`, nil, true},
+		{"unknown algorithm", `[{"limit":5,"window":"1m","algorithm":"leaky_bucket"}]`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := ParsePolicies(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policies)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		route        string
+		method, path string
+		want         bool
+	}{
+		{"", "GET", "/anything", true},
+		{"*", "GET", "/anything", true},
+		{"/api/v1/user/login", "POST", "/api/v1/user/login", true},
+		{"/api/v1/user/login", "POST", "/api/v1/user/logout", false},
+		{"POST /api/v1/author", "post", "/api/v1/author", true},
+		{"POST /api/v1/author", "GET", "/api/v1/author", false},
+		{"/api/v1/author/:id", "PUT", "/api/v1/author/42", true},
+		{"/api/v1/author/:id", "PUT", "/api/v1/author", false},
+		{"/api/v1/author/:id", "PUT", "/api/v1/author/42/books", false},
+		{"/api/v1/admin/*", "GET", "/api/v1/admin/config/reload", true},
+		{"/api/v1/admin/*", "GET", "/api/v1/authors", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			_, ok := Match([]Policy{{Route: tt.route}}, tt.method, tt.path)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+
+	first, _ := Match([]Policy{{Route: "/a", Limit: 1}, {Route: "*", Limit: 2}}, "GET", "/a")
+	assert.Equal(t, 1, first.Limit, "the first matching policy wins")
+}
+
+func TestTokenBucket(t *testing.T) {
+	p := policy(TokenBucket, 10, time.Minute) // a token every 6s
+
+	tests := []struct {
+		name       string
+		tokens     float64
+		elapsed    time.Duration
+		allowed    bool
+		remaining  int
+		reset      time.Duration
+		retryAfter time.Duration
+	}{
+		{"full bucket", 10, 0, true, 9, 6 * time.Second, 0},
+		{"refill is capped", 10, time.Hour, true, 9, 6 * time.Second, 0},
+		{"partial refill", 0, 9 * time.Second, true, 0, 57 * time.Second, 0},
+		{"empty bucket", 0, 0, false, 0, time.Minute, 6 * time.Second},
+		{"half a token", 0, 3 * time.Second, false, 0, 57 * time.Second, 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := refill(p, tt.tokens, tt.elapsed)
+			allowed := tokens >= 1
+			if allowed {
+				tokens--
+			}
+			result := bucketResult(p, tokens, allowed)
+
+			assert.Equal(t, tt.allowed, result.Allowed)
+			assert.Equal(t, 10, result.Limit)
+			assert.Equal(t, tt.remaining, result.Remaining)
+			assert.InDelta(t, tt.reset, result.Reset, float64(time.Millisecond))
+			assert.InDelta(t, tt.retryAfter, result.RetryAfter, float64(time.Millisecond))
+		})
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	p := policy(SlidingWindow, 10, time.Minute)
+
+	tests := []struct {
+		name              string
+		elapsed           time.Duration
+		previous, current int
+		allowed           bool
+		remaining         int
+		retryAfter        time.Duration
+	}{
+		{"empty", 0, 0, 1, true, 9, 0},
+		{"previous window weighs in", 30 * time.Second, 10, 4, true, 1, 0},
+		{"current window full", 30 * time.Second, 0, 10, false, 0, 30 * time.Second},
+		// 10*(60-e)/60 + 5 + 1 <= 10 from e = 36s.
+		{"previous window leaves room later", 30 * time.Second, 10, 5, false, 0, 6 * time.Second},
+		// The weighted count can't drop enough before the next window.
+		{"wait for the next window", 10 * time.Second, 10, 9, false, 0, 50 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := slidingWindow(p, tt.elapsed, tt.previous, tt.current, tt.allowed)
+			assert.Equal(t, tt.allowed, result.Allowed)
+			assert.Equal(t, tt.remaining, result.Remaining)
+			assert.Equal(t, time.Minute-tt.elapsed, result.Reset)
+			assert.InDelta(t, tt.retryAfter, result.RetryAfter, float64(time.Millisecond))
+
+			if !tt.allowed && tt.current < p.Limit {
+				// A request is allowed again after RetryAfter, not before.
+				at := tt.elapsed + result.RetryAfter
+				assert.LessOrEqual(t, slidingCount(p, at+time.Millisecond, tt.previous, tt.current)+1, float64(p.Limit))
+				assert.Greater(t, slidingCount(p, at-time.Millisecond, tt.previous, tt.current)+1, float64(p.Limit))
+			}
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	for _, algorithm := range []string{TokenBucket, SlidingWindow} {
+		t.Run(algorithm, func(t *testing.T) {
+			store := NewMemoryStore(time.Minute)
+			p := policy(algorithm, 3, time.Hour)
+			ctx := context.Background()
+
+			for i := 0; i < 3; i++ {
+				result, err := store.Allow(ctx, "a", p)
+				require.NoError(t, err)
+				assert.True(t, result.Allowed, "request %d", i)
+				assert.Equal(t, 2-i, result.Remaining)
+			}
+
+			result, err := store.Allow(ctx, "a", p)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Positive(t, result.RetryAfter)
+
+			other, err := store.Allow(ctx, "b", p)
+			require.NoError(t, err)
+			assert.True(t, other.Allowed, "each key has its counter")
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore keeps the counters in Redis, shared by every instance.
+type RedisStore struct {
+	Client *redis.Client
+	Prefix string
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		Client: client,
+		Prefix: "ratelimit:",
+	}
+}
+
+// tokenBucketScript refills and takes a token atomically.
+// KEYS[1] bucket; ARGV limit, window ms, now ms. Returns {allowed, tokens}.
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or limit
+local ts = tonumber(state[2]) or now
+local elapsed = math.max(0, now - ts)
+tokens = math.min(limit, tokens + elapsed * limit / window)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], window * 2)
+return {allowed, tostring(tokens)}
+`)
+
+// slidingWindowScript counts the request in the current window when the weighted
+// count leaves room for it.
+// KEYS[1] current window, KEYS[2] previous window; ARGV limit, window ms, elapsed ms.
+// Returns {allowed, current, previous}.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+if previous * (window - elapsed) / window + current + 1 > limit then
+	return {0, current, previous}
+end
+current = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], window * 2)
+return {1, current, previous}
+`)
+
+func (s *RedisStore) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	now := time.Now()
+	window := time.Duration(policy.Window)
+
+	if policy.Algorithm == TokenBucket {
+		reply, err := tokenBucketScript.Run(ctx, s.Client, []string{s.Prefix + key},
+			policy.Limit, window.Milliseconds(), now.UnixMilli()).Slice()
+		if err != nil {
+			return Result{}, err
+		}
+
+		tokens, _ := strconv.ParseFloat(toString(reply[1]), 64)
+		return bucketResult(policy, tokens, toInt(reply[0]) == 1), nil
+	}
+
+	start := windowStart(policy, now)
+	current := s.Prefix + key + ":" + strconv.FormatInt(start.UnixMilli(), 10)
+	previous := s.Prefix + key + ":" + strconv.FormatInt(start.Add(-window).UnixMilli(), 10)
+	elapsed := now.Sub(start)
+
+	reply, err := slidingWindowScript.Run(ctx, s.Client, []string{current, previous},
+		policy.Limit, window.Milliseconds(), elapsed.Milliseconds()).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return slidingWindow(policy, elapsed, toInt(reply[2]), toInt(reply[1]), toInt(reply[0]) == 1), nil
+}
+
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int64:
+		return int(v)
+	case string:
+		parsed, _ := strconv.Atoi(v)
+		return parsed
+	}
+	return 0
+}
+
+func toString(value interface{}) string {
+	if v, ok := value.(string); ok {
+		return v
+	}
+	return ""
+}
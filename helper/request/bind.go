@@ -0,0 +1,140 @@
+package request
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/fiber-go-template/config/utils"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/i18n"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Bind parses a request into a new T, applies its default tags and validates it.
+//
+// Sources are picked from the tags of T: path params for `params` tags, the query
+// string for `query` tags and the body (JSON or form) when one is sent, in that order.
+// Malformed input returns a 400 error, failed validation a 422 error with the
+// invalid fields named after their tags and messages in the locale of the request.
+func Bind[T any](c *fiber.Ctx) (req T, err error) {
+	if err = setDefaults(reflect.ValueOf(&req).Elem()); err != nil {
+		return req, apperror.Internal(err)
+	}
+
+	if hasTag(reflect.TypeOf(req), "params") {
+		if err = c.ParamsParser(&req); err != nil {
+			return req, apperror.BadRequest("invalid path parameter: %s", err.Error())
+		}
+	}
+
+	if hasTag(reflect.TypeOf(req), "query") {
+		if err = c.QueryParser(&req); err != nil {
+			return req, apperror.BadRequest("invalid query parameter: %s", err.Error())
+		}
+	}
+
+	if len(c.Body()) > 0 {
+		if err = c.BodyParser(&req); err != nil {
+			return req, apperror.BadRequest("invalid request body: %s", err.Error())
+		}
+	}
+
+	if err = utils.Validator().Struct(req); err != nil {
+		fields := utils.ValidatorErrors(err, i18n.Locale(c))
+		if len(fields) == 0 {
+			return req, apperror.Internal(err)
+		}
+		return req, apperror.Validation("request validation failed").WithFields(fields)
+	}
+
+	return req, nil
+}
+
+// hasTag reports whether any field of the struct type t, or of its embedded structs, has the tag key.
+func hasTag(t reflect.Type, key string) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup(key); ok {
+			return true
+		}
+		if field.Anonymous && hasTag(field.Type, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setDefaults sets the `default` tag value of every exported field, nested structs included.
+func setDefaults(value reflect.Value) error {
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := setDefaults(value.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw, ok := field.Tag.Lookup("default")
+		if !ok {
+			continue
+		}
+		if err := setValue(value.Field(i), raw); err != nil {
+			return fmt.Errorf("default of field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported kind %s", value.Kind())
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type itemRequest struct {
+	ID       string `params:"id" validate:"required"`
+	PageSize int    `query:"pageSize" default:"10" validate:"gte=1"`
+	Sort     string `query:"sort" default:"asc" validate:"oneof=asc desc"`
+	Name     string `json:"name" validate:"required,lte=5"`
+}
+
+func bind(t *testing.T, target, body string) (int, []byte) {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.ErrorHandler})
+	app.Post("/items/:id", func(c *fiber.Ctx) error {
+		req, err := Bind[itemRequest](c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(req)
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var raw json.RawMessage
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&raw))
+	return res.StatusCode, raw
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		want   itemRequest
+	}{
+		{"every source", "/items/7?pageSize=20&sort=desc", `{"name":"abc"}`, itemRequest{ID: "7", PageSize: 20, Sort: "desc", Name: "abc"}},
+		{"defaults", "/items/7", `{"name":"abc"}`, itemRequest{ID: "7", PageSize: 10, Sort: "asc", Name: "abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, raw := bind(t, tt.target, tt.body)
+			require.Equal(t, fiber.StatusOK, status, string(raw))
+
+			var got itemRequest
+			require.NoError(t, json.Unmarshal(raw, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+		fields []string
+	}{
+		{"malformed query", "/items/7?pageSize=ten", `{"name":"abc"}`, fiber.StatusBadRequest, nil},
+		{"malformed body", "/items/7", `{"name":`, fiber.StatusBadRequest, nil},
+		{"invalid fields", "/items/7?pageSize=0&sort=up", `{"name":"too long"}`, fiber.StatusUnprocessableEntity, []string{"name", "pageSize", "sort"}},
+		{"missing body", "/items/7", "", fiber.StatusUnprocessableEntity, []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, raw := bind(t, tt.target, tt.body)
+			assert.Equal(t, tt.status, status, string(raw))
+
+			var problem apperror.Problem
+			require.NoError(t, json.Unmarshal(raw, &problem))
+			var fields []string
+			for _, field := range problem.Errors {
+				fields = append(fields, field.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestBindLocale(t *testing.T) {
+	messages := map[string]string{}
+	for _, lang := range []string{"en", "id"} {
+		status, raw := bind(t, "/items/7?lang="+lang, `{}`)
+		require.Equal(t, fiber.StatusUnprocessableEntity, status)
+
+		var problem apperror.Problem
+		require.NoError(t, json.Unmarshal(raw, &problem))
+		require.Len(t, problem.Errors, 1)
+		messages[lang] = problem.Errors[0].Message
+	}
+
+	assert.NotEmpty(t, messages["en"])
+	assert.NotEqual(t, messages["en"], messages["id"], "the messages are translated")
+}
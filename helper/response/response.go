@@ -1,8 +1,73 @@
 package response
 
-// Base is the base object of all responses
+import (
+	"github.com/fiber-go-template/helper/i18n"
+	"github.com/fiber-go-template/helper/pagination"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Base is the base object of all success responses. Errors are rendered as
+// apperror.Problem by the error handler.
 type Base struct {
-	Data    *interface{} `json:"data,omitempty"`
-	Error   *string      `json:"error,omitempty"`
-	Message *string      `json:"message,omitempty"`
+	Success bool        `json:"success"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+	Meta    *Meta       `json:"meta,omitempty"`
+}
+
+// Meta is the paging info of a list response, either offset or cursor based.
+type Meta struct {
+	Pagination *pagination.Metadata       `json:"pagination,omitempty"`
+	Cursor     *pagination.CursorMetadata `json:"cursor,omitempty"`
+}
+
+// OK sends data with status 200.
+func OK[T any](c *fiber.Ctx, data T, message ...string) error {
+	return send(c, fiber.StatusOK, Base{Success: true, Message: first(message), Data: data})
+}
+
+// Created sends the created data with status 201.
+func Created[T any](c *fiber.Ctx, data T, message ...string) error {
+	return send(c, fiber.StatusCreated, Base{Success: true, Message: first(message), Data: data})
+}
+
+// Paginated sends a page of items and its paging info with status 200.
+func Paginated[T any](c *fiber.Ctx, page pagination.Response[T], message ...string) error {
+	items := page.Items
+	if items == nil {
+		items = make([]T, 0)
+	}
+
+	var meta *Meta
+	if page.Meta != nil || page.Cursor != nil {
+		meta = &Meta{Pagination: page.Meta, Cursor: page.Cursor}
+	}
+
+	return send(c, fiber.StatusOK, Base{Success: true, Message: first(message), Data: items, Meta: meta})
+}
+
+// Message sends a message without data with status 200.
+func Message(c *fiber.Ctx, message string) error {
+	return send(c, fiber.StatusOK, Base{Success: true, Message: message})
+}
+
+// NoContent sends an empty body with status 204.
+func NoContent(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func send(c *fiber.Ctx, status int, body Base) error {
+	// Messages are sent in the locale of the request.
+	if body.Message != "" {
+		body.Message = i18n.T(c, body.Message)
+	}
+
+	return c.Status(status).JSON(body)
+}
+
+func first(message []string) string {
+	if len(message) > 0 {
+		return message[0]
+	}
+	return ""
 }
@@ -3,11 +3,12 @@ package main
 //go:generate go run github.com/swaggo/swag/cmd/swag init
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/fiber-go-template/bootstrap"
 
 	_ "github.com/fiber-go-template/docs" // load API Docs files (Swagger)
-
-	_ "github.com/joho/godotenv/autoload" // load .env file automatically
 )
 
 // @title Go Fiber Boilerplate
@@ -19,5 +20,14 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	// Run the migrations embedded in the binary: apiserver migrate up|down|status|force.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := bootstrap.Migrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	bootstrap.AppServe()
 }
@@ -1,31 +1,127 @@
 package routes
 
 import (
+	"context"
+	"time"
+
 	"github.com/fiber-go-template/app/controllers"
 	"github.com/fiber-go-template/app/repository"
 	"github.com/fiber-go-template/app/services"
+	"github.com/fiber-go-template/config/settings"
 	"github.com/fiber-go-template/database"
+	"github.com/fiber-go-template/database/cache"
+	"github.com/fiber-go-template/helper/health"
+	"github.com/fiber-go-template/helper/idempotency"
+	"github.com/rs/zerolog/log"
 )
 
 type Injection struct {
+	Config           *settings.Config
+	Settings         *settings.Reloader
+	UserService      services.UserService
+	Idempotency      idempotency.Store
+	HealthController controllers.HealthController
 	AuthController   controllers.AuthController
+	AdminController  controllers.AdminController
 	AuthorController controllers.AuthorController
+	// scaffold:injection-fields
 }
 
 // Define Dependency Injection
-func CallDependenciesInjection() Injection {
-	DbConnect, _ := database.NewDBConnection()
+func CallDependenciesInjection(reloader *settings.Reloader) Injection {
+	cfg := reloader.Config()
+	DbConnect, err := database.NewDBConnection(cfg)
+	if err != nil {
+		if !DbConnect.Opened() {
+			log.Fatal().Err(err).Msg("Database is not configured.")
+		}
+		// The server starts not ready, see the readiness probe.
+		log.Error().Err(err).Msg("Database is not reachable.")
+	}
+	// Open the connections again when the database password rotates, until it succeeds.
+	connectedPassword := cfg.Database.Password
+	reloader.OnReload(func(_, current *settings.Config) {
+		if current.Database.Password == connectedPassword {
+			return
+		}
+		if err := DbConnect.Reconnect(current); err != nil {
+			log.Error().Err(err).Msg("Database is not reachable with the rotated password.")
+			return
+		}
+		connectedPassword = current.Database.Password
+		log.Info().Msg("Database reconnected with the rotated password.")
+	})
+	// Health
+	checker := health.NewChecker(time.Duration(cfg.Server.HealthCheckTimeoutSeconds) * time.Second)
+	checker.Register("sqlx", func(ctx context.Context) error {
+		return DbConnect.Query().PingContext(ctx)
+	})
+	checker.Register("gorm", func(ctx context.Context) error {
+		sqlDB, err := DbConnect.Orm().DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+	if versions, err := database.Migrations(cfg.Database.Type); err != nil {
+		log.Warn().Err(err).Msg("Schema version is not checked.")
+	} else if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		checker.Register("schema", func(ctx context.Context) error {
+			return DbConnect.CheckSchema(ctx, latest)
+		})
+	}
+	if cfg.Redis.Enabled() {
+		redisClient, err := cache.RedisClient(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Redis is not configured.")
+		}
+		checker.Register("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+	}
+	healthController := controllers.NewHealthController(checker)
+	// Idempotency
+	idempotencyStore := newIdempotencyStore(cfg, DbConnect)
+	// Cache
+	cacheStore, err := cache.NewStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cache is not configured.")
+	}
 	// Auth
 	userRepository := repository.NewUserRepository(DbConnect)
 	userService := services.NewUserService(userRepository)
-	authController := controllers.NewAuthController(userService)
+	authController := controllers.NewAuthController(userService, reloader)
+	// Admin
+	adminController := controllers.NewAdminController(reloader)
 	// Author
 	authorRepository := repository.NewAuthorRepository(DbConnect)
-	authorService := services.NewAuthorService(DbConnect, authorRepository)
+	authorService := services.NewAuthorService(DbConnect, authorRepository, cacheStore, cfg.Cache.TTL())
 	authorController := controllers.NewAuthorController(authorService)
+	// scaffold:injection
 
 	return Injection{
+		Config:           cfg,
+		Settings:         reloader,
+		UserService:      userService,
+		Idempotency:      idempotencyStore,
+		HealthController: healthController,
 		AuthController:   authController,
+		AdminController:  adminController,
 		AuthorController: authorController,
+		// scaffold:injection-return
 	}
 }
+
+// newIdempotencyStore returns the store of idempotency keys, in the database (default) or in Redis.
+func newIdempotencyStore(cfg *settings.Config, db database.DBConn) idempotency.Store {
+	if cfg.Idempotency.Backend == "redis" {
+		redisClient, err := cache.RedisClient(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Redis is not configured.")
+		}
+		return idempotency.NewRedisStore(redisClient)
+	}
+
+	return idempotency.NewDBStore(db, time.Hour)
+}
@@ -2,29 +2,47 @@ package routes
 
 import (
 	"github.com/fiber-go-template/app/middleware"
+	"github.com/fiber-go-template/config/constant"
+	"github.com/fiber-go-template/helper/apperror"
+	"github.com/fiber-go-template/helper/metrics"
 	"github.com/gofiber/fiber/v2"
 	swagger "github.com/gofiber/swagger"
 )
 
 func SetupRoutes(a *fiber.App, c Injection) {
 	SwaggerRoute(a)
+	HealthRoute(a, c)
 	// Create routes group.
 	route := a.Group("/api/v1")
+	// Replay POST retries sent with an Idempotency-Key header.
+	idempotent := middleware.Idempotency(c.Idempotency, c.Config.Idempotency.TTL())
+	// Require a valid JWT.
+	protected := middleware.JWTProtected(c.Settings)
 
 	// AUTH
 	userController := c.AuthController
 	route.Post("/user/login", userController.UserSignIn)
-	route.Post("/user/logout", middleware.JWTProtected(), userController.UserSignOut)
-	route.Post("/token/renew", middleware.JWTProtected(), userController.RenewTokens)
+	route.Post("/user/logout", protected, userController.UserSignOut)
+	route.Post("/token/renew", protected, userController.RenewTokens)
+
+	// ADMIN
+	adminController := c.AdminController
+	admin := route.Group("/admin", protected, middleware.RoleRequired(c.UserService, constant.AdminRoleName))
+	admin.Get("/log-level", adminController.GetLogLevel)
+	admin.Put("/log-level", adminController.SetLogLevel)
+	admin.Get("/config", adminController.GetConfig)
+	admin.Post("/config/reload", adminController.ReloadConfig)
 
 	// BOOK
 	authorController := c.AuthorController
-	route.Get("/authors", middleware.JWTProtected(), authorController.ResolveAll)
-	route.Get("/authors/all", middleware.JWTProtected(), authorController.GetAll)
+	route.Get("/authors", protected, authorController.ResolveAll)
+	route.Get("/authors/all", protected, authorController.GetAll)
 	route.Get("/author/:id", authorController.FindByID)
-	route.Post("/author", middleware.JWTProtected(), authorController.Create)
-	route.Put("/author/:id", middleware.JWTProtected(), authorController.Update)
-	route.Delete("/author/:id", middleware.JWTProtected(), authorController.Delete)
+	route.Post("/author", protected, idempotent, authorController.Create)
+	route.Put("/author/:id", protected, authorController.Update)
+	route.Delete("/author/:id", protected, authorController.Delete)
+
+	// scaffold:routes (generated resources are added above this line)
 }
 
 func SwaggerRoute(a *fiber.App) {
@@ -32,13 +50,23 @@ func SwaggerRoute(a *fiber.App) {
 	route.Get("*", swagger.HandlerDefault)
 }
 
+// HealthRoute serves the liveness and readiness probes.
+func HealthRoute(a *fiber.App, c Injection) {
+	healthController := c.HealthController
+	route := a.Group("/health")
+	route.Get("/live", healthController.Live)
+	route.Get("/ready", healthController.Ready)
+}
+
+// MetricsRoute serves the Prometheus metrics on /metrics.
+func MetricsRoute(a *fiber.App) {
+	a.Get("/metrics", metrics.Handler())
+}
+
 func NotFoundRoute(a *fiber.App) {
 	a.Use(
 		func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": true,
-				"msg":   "sorry, endpoint is not found",
-			})
+			return apperror.NotFound("sorry, endpoint is not found")
 		},
 	)
 }